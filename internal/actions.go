@@ -18,44 +18,67 @@
 package internal
 
 import (
-	"errors"
 	"fmt"
-	"strconv"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/noble-assets/orbiter/types/controller/action"
 	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/noble-assets/orbgen/internal/builder"
 )
 
+// actionSummaryReserved is the number of columns next to the addresses
+// in the summary of the current actions.
+const actionSummaryReserved = 32
+
+// feeFields contains the builder fields of the fee action inputs, in order.
+var feeFields = []string{builder.FieldFeeRecipient, builder.FieldBasisPoints}
+
 func (m Model) writeActionSelection(s *strings.Builder) {
 	// Header
-	s.WriteString(bold.Render("Orbiter Payload Generator"))
+	s.WriteString(m.styles.title.Render(m.messages.generatorTitle))
 	s.WriteString("\n\n")
 
 	// Explanation
 	if len(m.actions) == 0 {
-		s.WriteString("Welcome! This tool helps you build payloads for cross-chain operations.\n")
-		s.WriteString(
-			"To start, select if you want to add a so-called " +
-				bold.Render("action") +
-				" to the payload.\n\n",
-		)
-		s.WriteString(
-			"Actions are optional operations that run before forwarding (e.g. fee payments).\n",
-		)
-		s.WriteString("The selected actions will be run sequentially, so bear that in mind.\n\n")
+		fmt.Fprintf(s, m.messages.welcome, m.styles.emphasis.Render(m.messages.actionTerm))
 	} else {
-		s.WriteString("Add another action or continue to forwarding selection.\n")
-		s.WriteString("Current actions: ")
+		next := m.messages.nextForwarding
+		if m.presetForwarding != nil {
+			next = m.messages.nextConfirmation
+		}
+		fmt.Fprintf(s, m.messages.addAnother, next)
+		s.WriteString(m.messages.currentActions)
 		for i, act := range m.actions {
-			if i > 0 {
-				s.WriteString(", ")
-			}
-			s.WriteString(act.Id.String())
+			fmt.Fprintf(s, "  %d. %s\n", i+1, m.summarizeAction(act, actionSummaryReserved))
 		}
+		s.WriteString("\n")
+	}
+
+	switch {
+	case m.opts.Imported != nil:
+		s.WriteString(m.styles.hint.Render(
+			fmt.Sprintf(m.messages.importedHint, m.presetForwarding.ProtocolId.String()),
+		))
+		s.WriteString("\n\n")
+	case m.presetForwarding != nil:
+		s.WriteString(m.styles.hint.Render(
+			fmt.Sprintf(m.messages.configuredHint, m.presetForwarding.ProtocolId.String()),
+		))
+		s.WriteString("\n\n")
+	}
+
+	if len(m.actions) == 0 && m.presetForwarding == nil {
+		s.WriteString(m.styles.hint.Render(m.messages.importHint))
+		s.WriteString("\n\n")
+	}
+
+	if len(m.history.undo) > 0 || len(m.history.redo) > 0 {
+		s.WriteString(m.styles.hint.Render(m.messages.undoHint))
 		s.WriteString("\n\n")
 	}
 
@@ -64,34 +87,53 @@ func (m Model) writeActionSelection(s *strings.Builder) {
 }
 
 func (m Model) writeFeeActionSelection(s *strings.Builder) {
-	s.WriteString(bold.Render("Configure Fee Action"))
+	s.WriteString(m.styles.title.Render("Configure Fee Action"))
 	s.WriteString("\n\n")
 	s.WriteString("Fee actions allow you to collect a percentage of the transaction amount.\n")
-	s.WriteString("The recipient will receive the specified percentage as a fee.\n\n")
+	s.WriteString("The recipient will receive the specified percentage as a fee.\n")
+	s.WriteString(
+		"The note is optional and only included in the JSON output, not in the payload.\n\n",
+	)
 
-	for _, input := range m.actionInputs {
+	for i, input := range m.actionInputs {
 		s.WriteString(input.View() + "\n")
+		if i == 1 {
+			m.writeBasisPointsHint(s, input.Value())
+		}
 	}
 
-	s.WriteString("\nUse Tab/Shift+Tab to navigate fields, Enter to add action, Ctrl+C to quit")
+	m.writeKeyringHint(s)
+	s.WriteString("\nUse Tab/Shift+Tab to navigate fields, Ctrl+E to fill in an example,\n")
+	s.WriteString("Enter to add action, Ctrl+C to quit")
 }
 
-func (m Model) initFeeActionInput() Model {
-	inputs := make([]textinput.Model, 2)
+// writeBasisPointsHint shows the basis points of percentage inputs to avoid any ambiguity,
+// including the exact value if it was rounded to a whole number of basis points.
+func (m Model) writeBasisPointsHint(s *strings.Builder, value string) {
+	value = strings.TrimSpace(value)
+	percentage, isPercentage := strings.CutSuffix(value, "%")
+	if !isPercentage {
+		return
+	}
 
-	inputs[0] = textinput.New()
-	inputs[0].Placeholder = "Fee recipient address"
-	inputs[0].CharLimit = 100
-	inputs[0].Width = 50
+	basisPoints, err := builder.ParseBasisPoints(m.opts, value)
+	if err != nil {
+		return
+	}
 
-	inputs[1] = textinput.New()
-	inputs[1].Placeholder = "Basis points (e.g. 100 for 1%)"
-	inputs[1].CharLimit = 5
-	inputs[1].Width = 30
+	hint := fmt.Sprintf("  = %d basis points", basisPoints)
+	if exact, err := builder.PercentageToBasisPoints(percentage); err == nil && !exact.IsInteger() {
+		hint += fmt.Sprintf(" (rounded from %s)", strings.TrimRight(exact.String(), "0"))
+	}
 
-	m.actionInputs = inputs
-	m.state = feeActionInput
-	focusIndex = 0
+	s.WriteString(m.styles.hint.Render(hint))
+	s.WriteString("\n")
+}
+
+func (m Model) initFeeActionInput() Model {
+	m.actionInputs = m.newFeeInputs()
+	m.state = actionInput
+	m.focusIndex = 0
 
 	// Focus the first input
 	m.actionInputs[0].Focus()
@@ -99,90 +141,108 @@ func (m Model) initFeeActionInput() Model {
 	return m
 }
 
-func (m Model) processFeeAction() (tea.Model, tea.Cmd) {
-	recipientAddr := strings.TrimSpace(m.actionInputs[0].Value())
-	basisPointsStr := strings.TrimSpace(m.actionInputs[1].Value())
-
-	if recipientAddr == "" {
-		m.err = errors.New("recipient address is required")
+// newFeeInputs returns the recipient, basis points and note inputs of a fee action.
+// The recipient is prefilled with the fee recipient of the profile, if any.
+func (m Model) newFeeInputs() []textinput.Model {
+	inputs := make([]textinput.Model, 3)
 
-		return m, nil
-	}
-	if basisPointsStr == "" {
-		m.err = errors.New("basis points is required")
+	inputs[0] = textinput.New()
+	inputs[0].Placeholder = "Fee recipient address"
+	inputs[0].CharLimit = 100
+	inputs[0].Width = 50
+	inputs[0].SetValue(m.opts.Profile.FeeRecipient)
 
-		return m, nil
-	}
+	inputs[1] = textinput.New()
+	inputs[1].Placeholder = "Basis points (e.g. 100 or 1% for 1%)"
+	inputs[1].CharLimit = 8
+	inputs[1].Width = 30
 
-	basisPoints, err := strconv.ParseUint(basisPointsStr, 10, 32)
-	if err != nil {
-		m.err = fmt.Errorf("invalid basis points: %w", err)
+	inputs[2] = textinput.New()
+	inputs[2].Placeholder = "Note (optional; only included in the JSON output)"
+	inputs[2].CharLimit = 100
+	inputs[2].Width = 50
 
-		return m, nil
-	}
+	return inputs
+}
 
-	feeAttr := action.FeeAttributes{
-		FeesInfo: []*action.FeeInfo{
-			{
-				Recipient:   recipientAddr,
-				BasisPoints: uint32(basisPoints),
-			},
-		},
+func (m Model) processFeeAction() (tea.Model, tea.Cmd) {
+	feeAction, err := buildFeeAction(m.opts, m.actionInputs)
+	if err != nil {
+		return m.inputError(err, m.actionInputs, feeFields)
 	}
 
-	if err = feeAttr.Validate(); err != nil {
-		m.err = fmt.Errorf("invalid fee attributes: %w", err)
+	m = m.setActions(append(slices.Clone(m.actions), feeAction))
+	m = m.setActionNote(feeAction, m.actionInputs[2].Value())
+	m.err = nil
 
-		return m, nil
-	}
-
-	feeAction := core.Action{
-		Id: core.ACTION_FEE,
-	}
+	return m.initActionSelection(), nil
+}
 
-	err = feeAction.SetAttributes(&feeAttr)
+// buildFeeAction builds the fee action from the values of the recipient and basis points inputs.
+func buildFeeAction(opts builder.Options, inputs []textinput.Model) (*core.Action, error) {
+	basisPoints, err := builder.ParseBasisPoints(opts, strings.TrimSpace(inputs[1].Value()))
 	if err != nil {
-		m.err = fmt.Errorf("failed to set action attributes: %w", err)
-
-		return m, nil
+		return nil, err
 	}
 
-	if err = feeAction.Validate(); err != nil {
-		m.err = fmt.Errorf("invalid fee action: %w", err)
+	return builder.NewFeeAction(strings.TrimSpace(inputs[0].Value()), basisPoints)
+}
 
-		return m, nil
+// setActionNote sets the note of the given action, which is only included in the JSON output.
+func (m Model) setActionNote(act *core.Action, note string) Model {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return m
 	}
 
-	m.actions = append(m.actions, &feeAction)
+	// NOTE: the notes are keyed by action, so that they are kept when undoing changes.
+	m.actionNotes = maps.Clone(m.actionNotes)
+	if m.actionNotes == nil {
+		m.actionNotes = make(map[*core.Action]string)
+	}
+	m.actionNotes[act] = note
 
-	return m.initActionSelection(), nil
+	return m
 }
 
 func (m Model) initActionSelection() Model {
-	actionItems := []list.Item{
-		item{title: core.ACTION_FEE.String(), desc: "Add fee payment action"},
-		item{title: core.ACTION_SWAP.String(), desc: "Add token swap action"},
-		item{title: "No more actions", desc: "Proceed to forwarding selection"},
-	}
-
-	l := list.New(actionItems, list.NewDefaultDelegate(), 0, 0)
-	l.Title = "Select an action to add:"
+	descriptors := actionDescriptors()
+	actionItems := make([]item, 0, len(descriptors)+1)
+	for _, d := range descriptors {
+		desc, found := m.messages.actionDescriptions[d.id]
+		if !found {
+			desc = d.desc
+		}
 
-	// Apply stored window dimensions if we have them
-	if m.windowWidth > 0 && m.windowHeight > 0 {
-		l.SetWidth(m.windowWidth)
-		l.SetHeight(m.windowHeight - 3)
+		actionItems = append(actionItems, item{
+			title:       d.id.String(),
+			desc:        desc,
+			implemented: d.implemented,
+		})
+	}
+	proceed := m.messages.proceedForwarding
+	if m.presetForwarding != nil {
+		proceed = m.messages.proceedConfirmation
 	}
+	actionItems = append(actionItems, item{
+		title:       m.messages.noMoreActions,
+		desc:        proceed,
+		value:       noMoreActions,
+		implemented: true,
+	})
 
-	m.list = l
+	l := list.New(m.listItems(actionItems...), list.NewDefaultDelegate(), 0, 0)
+	l.Title = m.messages.actionListTitle
+
+	m.list = m.resizeList(l)
 	m.state = actionSelection
 
 	return m
 }
 
-func (m Model) updateActionInputs(msg tea.Msg) tea.Cmd {
+func (m Model) updateActionInputs(msg tea.Msg) (Model, tea.Cmd) {
 	if len(m.actionInputs) == 0 {
-		return nil
+		return m, nil
 	}
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
@@ -192,26 +252,23 @@ func (m Model) updateActionInputs(msg tea.Msg) tea.Cmd {
 
 			// Update focus position
 			if s == Up || s == ShiftTab {
-				if focusIndex > 0 {
-					focusIndex--
+				if m.focusIndex > 0 {
+					m.focusIndex--
 				}
 			} else {
-				if focusIndex < len(m.actionInputs)-1 {
-					focusIndex++
+				if m.focusIndex < len(m.actionInputs)-1 {
+					m.focusIndex++
 				}
 			}
 
 			// Update focus for all inputs
-			cmds := make([]tea.Cmd, len(m.actionInputs))
-			for i := range m.actionInputs {
-				if i == focusIndex {
-					cmds[i] = m.actionInputs[i].Focus()
-				} else {
-					m.actionInputs[i].Blur()
-				}
-			}
+			return m.focusInput(m.actionInputs, m.focusIndex)
+		case CtrlE:
+			m.fillExample(m.actionInputs)
 
-			return tea.Batch(cmds...)
+			return m, nil
+		case CtrlL:
+			return m.initKeyringSelection(), nil
 		}
 	}
 
@@ -221,5 +278,5 @@ func (m Model) updateActionInputs(msg tea.Msg) tea.Cmd {
 		m.actionInputs[i], cmds[i] = m.actionInputs[i].Update(msg)
 	}
 
-	return tea.Batch(cmds...)
+	return m, tea.Batch(cmds...)
 }
@@ -0,0 +1,143 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types/controller/action"
+	"github.com/noble-assets/orbiter/types/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+func TestBuildFeeAction(t *testing.T) {
+	recipient := testutil.NewNobleAddress()
+
+	testCases := []struct {
+		name        string
+		recipient   string
+		basisPoints string
+		expErr      error
+		expField    string
+		expMsg      string
+	}{
+		{
+			name:        "valid recipient and basis points",
+			recipient:   recipient,
+			basisPoints: "100",
+		},
+		{
+			name:        "surrounding whitespace is trimmed",
+			recipient:   " " + recipient + "\t",
+			basisPoints: " 100 ",
+		},
+		{
+			name:        "empty recipient",
+			basisPoints: "100",
+			expErr:      builder.ErrEmptyRecipient,
+			expField:    builder.FieldFeeRecipient,
+			expMsg:      "recipient address is required",
+		},
+		{
+			name:        "whitespace recipient",
+			recipient:   "   ",
+			basisPoints: "100",
+			expErr:      builder.ErrEmptyRecipient,
+			expField:    builder.FieldFeeRecipient,
+			expMsg:      "recipient address is required",
+		},
+		{
+			name:      "empty basis points",
+			recipient: recipient,
+			expErr:    builder.ErrInvalidBasisPoints,
+			expField:  builder.FieldBasisPoints,
+			expMsg:    "basis points is required",
+		},
+		{
+			name:        "non-numeric basis points",
+			recipient:   recipient,
+			basisPoints: "ten",
+			expErr:      builder.ErrInvalidBasisPoints,
+			expField:    builder.FieldBasisPoints,
+			expMsg:      `invalid basis points: strconv.ParseUint: parsing "ten": invalid syntax`,
+		},
+		{
+			name:        "zero basis points",
+			recipient:   recipient,
+			basisPoints: "0",
+			expErr:      builder.ErrBPSOutOfRange,
+			expField:    builder.FieldBasisPoints,
+		},
+		{
+			name:        "basis points above 100%",
+			recipient:   recipient,
+			basisPoints: "10001",
+			expErr:      builder.ErrBPSOutOfRange,
+			expField:    builder.FieldBasisPoints,
+		},
+		{
+			name:        "invalid recipient",
+			recipient:   "cosmos1invalid",
+			basisPoints: "100",
+			expErr:      builder.ErrInvalidRecipient,
+			expField:    builder.FieldFeeRecipient,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputs := make([]textinput.Model, feeInputCount)
+			for i := range inputs {
+				inputs[i] = textinput.New()
+			}
+			inputs[0].SetValue(tc.recipient)
+			inputs[1].SetValue(tc.basisPoints)
+
+			act, err := buildFeeAction(builder.Options{}, inputs)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				require.Nil(t, act)
+
+				var builderErr *builder.Error
+				require.ErrorAs(t, err, &builderErr)
+				require.Equal(t, tc.expField, builderErr.Field)
+				if tc.expMsg != "" {
+					require.EqualError(t, err, tc.expMsg)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, core.ACTION_FEE, act.Id)
+
+			attr, err := act.CachedAttributes()
+			require.NoError(t, err)
+
+			fee, ok := attr.(*action.FeeAttributes)
+			require.True(t, ok, "expected fee attributes; got: %T", attr)
+			require.Len(t, fee.FeesInfo, 1)
+			require.Equal(t, recipient, fee.FeesInfo[0].Recipient)
+			require.Equal(t, uint32(100), fee.FeesInfo[0].BasisPoints)
+		})
+	}
+}
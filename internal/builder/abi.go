@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// LoadABI reads the contract ABI, that passthrough payloads are validated against.
+// The file contains either the ABI itself or a build artifact with an "abi" field,
+// as written by Hardhat or Foundry.
+func LoadABI(path string) (*abi.ABI, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ABI file: %w", err)
+	}
+
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if err = json.Unmarshal(bz, &artifact); err == nil && len(artifact.ABI) > 0 {
+		bz = artifact.ABI
+	}
+
+	contractABI, err := abi.JSON(bytes.NewReader(bz))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ABI file: %w", err)
+	}
+
+	return &contractABI, nil
+}
+
+// DecodeCalldata decodes the given calldata with the method of the contract ABI
+// matching its function selector, and returns the call in a human-readable form,
+// e.g. transfer(to: 0x..., value: 100). The calldata has to decode cleanly,
+// so that arguments with trailing or non-canonically encoded bytes are rejected.
+func DecodeCalldata(contractABI *abi.ABI, calldata []byte) (string, error) {
+	if len(calldata) < 4 {
+		return "", fmt.Errorf(
+			"calldata is too short for a function selector; got %d bytes",
+			len(calldata),
+		)
+	}
+
+	method, err := contractABI.MethodById(calldata[:4])
+	if err != nil {
+		hint := ""
+		if bytes.HasPrefix(calldata, []byte("0x")) {
+			hint = "; the payload is hex text, use @path to pass binary calldata"
+		}
+
+		return "", fmt.Errorf(
+			"unknown function selector %s%s",
+			hexutil.Encode(calldata[:4]),
+			hint,
+		)
+	}
+
+	args, err := method.Inputs.Unpack(calldata[4:])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode arguments of %s: %w", method.Sig, err)
+	}
+
+	// NOTE: unpacking ignores trailing bytes, so the arguments are encoded again
+	// to ensure that the calldata is exactly the canonical encoding of the call.
+	packed, err := method.Inputs.Pack(args...)
+	if err != nil || !bytes.Equal(packed, calldata[4:]) {
+		return "", fmt.Errorf("arguments of %s are not canonically encoded", method.Sig)
+	}
+
+	formatted := make([]string, 0, len(args))
+	for i, arg := range args {
+		name := method.Inputs[i].Name
+		if name == "" {
+			name = method.Inputs[i].Type.String()
+		}
+
+		formatted = append(formatted, name+": "+formatABIValue(arg))
+	}
+
+	return method.Name + "(" + strings.Join(formatted, ", ") + ")", nil
+}
+
+// formatABIValue returns the human-readable representation of a decoded argument,
+// where byte arrays and slices are shown in hex instead of as list of numbers.
+// Types with their own representation, like checksummed addresses, keep it.
+func formatABIValue(value any) string {
+	if s, ok := value.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() { //nolint:exhaustive // other kinds are formatted by fmt
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+
+		bz := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(bz), v)
+
+		return hexutil.Encode(bz)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// ErrInvalidArgs is returned for positional arguments, that do not match any supported form.
+var ErrInvalidArgs = errors.New("invalid arguments")
+
+// SpecFromArgs creates the spec for a payload without actions
+// from positional command line arguments, which support the common forwardings in a terse form:
+//
+//	cctp <domain> <mint-recipient> [destination-caller]
+//	internal <recipient>
+func SpecFromArgs(args []string) (Spec, error) {
+	if len(args) == 0 {
+		return Spec{}, fmt.Errorf("%w: expected a protocol", ErrInvalidArgs)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "cctp":
+		if len(args) < 3 || len(args) > 4 {
+			return Spec{}, fmt.Errorf(
+				"%w: expected cctp <domain> <mint-recipient> [destination-caller]",
+				ErrInvalidArgs,
+			)
+		}
+
+		domain, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return Spec{}, fmt.Errorf("%w: invalid destination domain %q", ErrInvalidArgs, args[1])
+		}
+
+		cctp := &CCTPSpec{DestinationDomain: uint32(domain), MintRecipient: args[2]}
+		if len(args) == 4 {
+			cctp.DestinationCaller = args[3]
+		}
+
+		return Spec{
+			Forwarding: ForwardingSpec{Protocol: core.PROTOCOL_CCTP.String(), CCTP: cctp},
+		}, nil
+	case "internal":
+		if len(args) != 2 {
+			return Spec{}, fmt.Errorf("%w: expected internal <recipient>", ErrInvalidArgs)
+		}
+
+		return Spec{
+			Forwarding: ForwardingSpec{
+				Protocol: core.PROTOCOL_INTERNAL.String(),
+				Internal: &InternalSpec{Recipient: args[1]},
+			},
+		}, nil
+	default:
+		return Spec{}, fmt.Errorf("%w: unknown protocol %q", ErrInvalidArgs, args[0])
+	}
+}
@@ -0,0 +1,150 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package builder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// BroadcastPlaceholder is replaced by the path of the unsigned transaction file
+// in the arguments of the broadcast command.
+const BroadcastPlaceholder = "{}"
+
+// broadcastResponse contains the fields of the JSON transaction response of a Cosmos SDK
+// CLI, which are required to tell whether the broadcast succeeded.
+type broadcastResponse struct {
+	TxHash string `json:"txhash"`
+	Code   uint32 `json:"code"`
+	RawLog string `json:"raw_log"`
+}
+
+// BroadcastTx writes the unsigned transaction to a temporary file and runs the given
+// command to sign and broadcast it, e.g. a script calling `nobled tx sign` and
+// `nobled tx broadcast`. The path of the file replaces BroadcastPlaceholder in the
+// arguments, or is appended if there is no placeholder.
+//
+// The command inherits stdin and stderr, so that it can ask for a passphrase.
+// Its output must contain the transaction response of a Cosmos SDK CLI,
+// either as JSON or as text, from which the transaction hash is returned.
+func BroadcastTx(ctx context.Context, command []string, tx string) (string, error) {
+	if len(command) == 0 {
+		return "", errors.New("broadcast command is empty")
+	}
+
+	f, err := os.CreateTemp("", "orbgen-tx-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create transaction file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.WriteString(tx + "\n"); err != nil {
+		f.Close()
+
+		return "", fmt.Errorf("failed to write transaction file: %w", err)
+	}
+
+	if err = f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write transaction file: %w", err)
+	}
+
+	args := make([]string, 0, len(command))
+	replaced := false
+	for _, arg := range command[1:] {
+		if strings.Contains(arg, BroadcastPlaceholder) {
+			arg = strings.ReplaceAll(arg, BroadcastPlaceholder, f.Name())
+			replaced = true
+		}
+		args = append(args, arg)
+	}
+	if !replaced {
+		args = append(args, f.Name())
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], args...) //nolint:gosec // configured by the user
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err = cmd.Run(); err != nil {
+		return "", fmt.Errorf("broadcast command failed: %w", err)
+	}
+
+	return parseBroadcastOutput(stdout.String())
+}
+
+// parseBroadcastOutput returns the transaction hash from the output of the broadcast command,
+// which is either the JSON or the text encoding of the transaction response.
+// Responses with a non-zero code were rejected by the node and are returned as error.
+func parseBroadcastOutput(output string) (string, error) {
+	var res broadcastResponse
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &res); err != nil {
+		res = parseBroadcastText(output)
+	}
+
+	if res.TxHash == "" {
+		return "", fmt.Errorf(
+			"no transaction hash in the output of the broadcast command: %q",
+			output,
+		)
+	}
+
+	if res.Code != 0 {
+		return "", fmt.Errorf(
+			"transaction %s was rejected with code %d: %s",
+			res.TxHash,
+			res.Code,
+			res.RawLog,
+		)
+	}
+
+	return res.TxHash, nil
+}
+
+// parseBroadcastText reads the transaction response from its text encoding,
+// which contains one "key: value" pair per line.
+func parseBroadcastText(output string) broadcastResponse {
+	var res broadcastResponse
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		switch strings.TrimSpace(key) {
+		case "txhash":
+			res.TxHash = value
+		case "code":
+			_, _ = fmt.Sscan(value, &res.Code)
+		case "raw_log":
+			res.RawLog = value
+		}
+	}
+
+	return res
+}
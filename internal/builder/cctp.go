@@ -0,0 +1,452 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// DefaultMaxPassthroughSize is the maximum passthrough payload size in bytes,
+// that is used for CCTP domains without a known limit, unless a limit is configured in the options.
+const DefaultMaxPassthroughSize = 64 * 1024
+
+// ParseDomain parses the given input as a CCTP destination domain.
+func ParseDomain(input string) (uint32, error) {
+	if input == "" {
+		return 0, newError(
+			ErrInvalidDomain,
+			FieldDestinationDomain,
+			"destination domain is required",
+		)
+	}
+
+	domain, err := strconv.ParseUint(input, 10, 32)
+	if err != nil {
+		return 0, newError(
+			ErrInvalidDomain,
+			FieldDestinationDomain,
+			"invalid destination domain: %w",
+			err,
+		)
+	}
+
+	return uint32(domain), nil
+}
+
+// NewCCTPForwarding creates a validated CCTP forwarding from the user provided inputs.
+// The mint recipient and destination caller can be passed as hex, bech32 or base64 strings,
+// or as 'r' to generate random bytes. The destination caller can be set to 'self'
+// to use the same bytes as the mint recipient, or to the name of a known caller
+// of the domain. If enabled in the options, the mint recipient
+// can also be passed as an ENS name. The passthrough payload can be read from a file
+// by passing its path prefixed with '@', and is validated against the contract ABI if set.
+func NewCCTPForwarding(
+	opts Options,
+	domain uint32,
+	mintRecipientStr, destCallerStr, passthroughStr string,
+) (*core.Forwarding, error) {
+	// NOTE: The domain is checked before the orbiter validation,
+	// to be able to tell which of the inputs is invalid.
+	if domain == forwarding.CCTPNobleDomain {
+		return nil, newError(
+			ErrInvalidDomain,
+			FieldDestinationDomain,
+			"destination domain cannot be Noble",
+		)
+	}
+
+	if mintRecipientStr == "" {
+		return nil, newError(
+			ErrEmptyRecipient,
+			FieldMintRecipient,
+			"mint recipient cannot be empty",
+		)
+	}
+
+	var (
+		mintRecipient []byte
+		err           error
+	)
+	switch {
+	case mintRecipientStr == "r":
+		mintRecipient = testutil.RandomBytes(32)
+	case IsENSName(mintRecipientStr):
+		var resolved []byte
+		resolved, err = resolveENSName(opts.ENSRPC, mintRecipientStr)
+		if err == nil {
+			mintRecipient, err = toAddressBytes(opts, domain, opts.MintRecipientPadding, resolved)
+		}
+	default:
+		mintRecipient, err = decodeAddress(
+			opts,
+			domain,
+			opts.MintRecipientPadding,
+			mintRecipientStr,
+		)
+	}
+	if err != nil {
+		return nil, newError(
+			ErrInvalidAddress,
+			FieldMintRecipient,
+			"invalid mint recipient: %w",
+			err,
+		)
+	}
+
+	// NOTE: funds minted to the zero address are unrecoverable, while a zero destination
+	// caller is valid and allows anyone to receive the message on the destination.
+	if !opts.AllowZeroRecipient && bytes.Equal(mintRecipient, make([]byte, 32)) {
+		return nil, newError(
+			ErrZeroRecipient,
+			FieldMintRecipient,
+			"mint recipient is the zero address, which would make the funds unrecoverable",
+		)
+	}
+
+	var destCaller []byte
+	switch destCallerStr {
+	case "":
+		// The destination caller is optional
+	case "r":
+		destCaller = testutil.RandomBytes(32)
+	case "self":
+		destCaller = slices.Clone(mintRecipient)
+	default:
+		if caller, found := LookupKnownCaller(opts, domain, destCallerStr); found {
+			destCallerStr = caller.Address
+		}
+
+		destCaller, err = decodeAddress(
+			opts,
+			domain,
+			opts.DestinationCallerPadding,
+			destCallerStr,
+		)
+		if err != nil {
+			return nil, newError(
+				ErrInvalidAddress,
+				FieldDestinationCaller,
+				"invalid destination caller: %w",
+				err,
+			)
+		}
+	}
+
+	passthroughPayload, err := parsePassthrough(passthroughStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := maxPassthroughSize(opts, domain); len(passthroughPayload) > int(limit) {
+		return nil, newError(
+			ErrPassthroughTooLong,
+			FieldPassthrough,
+			"passthrough payload is too long for %s; max %d bytes; got: %d",
+			CCTPDomainName(domain),
+			limit,
+			len(passthroughPayload),
+		)
+	}
+
+	if opts.PassthroughABI != nil && len(passthroughPayload) > 0 {
+		if _, err = DecodeCalldata(opts.PassthroughABI, passthroughPayload); err != nil {
+			return nil, newError(
+				ErrInvalidPassthrough,
+				FieldPassthrough,
+				"passthrough payload does not match the contract ABI: %w",
+				err,
+			)
+		}
+	}
+
+	cctpForwarding, err := forwarding.NewCCTPForwarding(
+		domain,
+		mintRecipient,
+		destCaller,
+		passthroughPayload,
+	)
+	if err != nil {
+		return nil, cctpForwardingError(err)
+	}
+
+	return cctpForwarding, nil
+}
+
+// cctpForwardingError annotates errors of the orbiter validation with the field
+// they most likely pertain to, which is derived from the error message.
+// Errors that cannot be associated with a field are returned without a field.
+func cctpForwardingError(err error) error {
+	msg := strings.ToLower(err.Error())
+
+	var kind error
+	var field string
+	switch {
+	case strings.Contains(msg, "domain"):
+		kind, field = ErrInvalidDomain, FieldDestinationDomain
+	case strings.Contains(msg, "mint recipient"):
+		kind, field = ErrInvalidAddress, FieldMintRecipient
+	case strings.Contains(msg, "caller"):
+		kind, field = ErrInvalidAddress, FieldDestinationCaller
+	case strings.Contains(msg, "passthrough"):
+		kind, field = ErrInvalidPassthrough, FieldPassthrough
+	default:
+		return fmt.Errorf("failed to create CCTP forwarding: %w", err)
+	}
+
+	return newError(kind, field, "failed to create CCTP forwarding: %w", err)
+}
+
+// parsePassthrough returns the passthrough payload bytes for the given input.
+// Inputs prefixed with '@' are interpreted as a path to a file containing the payload.
+//
+// Other inputs are taken as raw string and have to be valid UTF-8, to not include
+// artifacts of pasting from rich-text sources. Binary payloads have to be read from a file.
+func parsePassthrough(input string) ([]byte, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	path, isFile := strings.CutPrefix(input, "@")
+	if !isFile {
+		if !utf8.ValidString(input) {
+			return nil, newError(
+				ErrInvalidPassthrough,
+				FieldPassthrough,
+				"passthrough payload has invalid UTF-8 at byte %d; use @path for binary data",
+				invalidUTF8Offset(input),
+			)
+		}
+
+		return []byte(input), nil
+	}
+
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, newError(
+			ErrInvalidPassthrough,
+			FieldPassthrough,
+			"failed to read passthrough payload file: %w",
+			err,
+		)
+	}
+
+	return bz, nil
+}
+
+// invalidUTF8Offset returns the byte offset of the first invalid UTF-8 sequence in the input.
+//
+// NOTE: a correctly encoded U+FFFD is valid, so that only its width tells it apart
+// from the error returned for invalid sequences.
+func invalidUTF8Offset(input string) int {
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		if r == utf8.RuneError && size == 1 {
+			return i
+		}
+		i += size
+	}
+
+	return -1
+}
+
+// decodeAddress decodes a string as either a hex, bech32 or base64 encoded address.
+// The encoding is detected for each input separately, so that the fields of a forwarding
+// can use different encodings. It returns the address aligned in a 32 byte slice according
+// to the padding mode, or an error if the input is invalid, which suggests a correction
+// for common mistakes.
+func decodeAddress(opts Options, domain uint32, padding Padding, input string) ([]byte, error) {
+	decoded, err := decodeAddressBytes(input)
+	if err == nil {
+		decoded, err = toAddressBytes(opts, domain, padding, decoded)
+	}
+
+	if err != nil {
+		if hint := decodeHint(input); hint != "" {
+			return nil, fmt.Errorf("%w; %s", err, hint)
+		}
+
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// decodeAddressBytes returns the bytes of a hex, bech32 or base64 encoded address,
+// without aligning them to 32 bytes.
+func decodeAddressBytes(input string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(input, "0x"):
+		decoded, err := hexutil.Decode(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode hex: %w", err)
+		}
+
+		return decoded, nil
+	case isBech32(input):
+		_, decoded, err := bech32.DecodeAndConvert(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode bech32: %w", err)
+		}
+
+		return decoded, nil
+	default:
+		decoded, err := base64.StdEncoding.DecodeString(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64: %w", err)
+		}
+
+		return decoded, nil
+	}
+}
+
+// isBech32 returns whether the input is a bech32 encoded address with a valid checksum.
+//
+// NOTE: bech32 addresses only contain characters of the base64 alphabet,
+// so the checksum is what tells them apart from base64 encoded addresses.
+func isBech32(input string) bool {
+	_, _, err := bech32.DecodeAndConvert(input)
+
+	return err == nil
+}
+
+// decodeHint returns a suggestion to correct an address, that could not be decoded,
+// based on common mistakes. It returns an empty string if no mistake is detected.
+func decodeHint(input string) string {
+	if digits, isHex := strings.CutPrefix(input, "0x"); isHex {
+		if len(digits)%2 != 0 {
+			return "check the hex digits for a missing or extra one"
+		}
+
+		return ""
+	}
+
+	switch {
+	case strings.HasPrefix(input, "0X"):
+		return "did you mean to use a lowercase 0x prefix?"
+	case input != "" && strings.Trim(input, "0123456789abcdefABCDEF") == "":
+		return "did you mean to prefix 0x for hex input?"
+	case strings.ContainsAny(input, "-_"):
+		return "this looks like URL-safe base64; replace '-' with '+' and '_' with '/'"
+	case len(input)%4 != 0 && !strings.HasSuffix(input, "="):
+		return fmt.Sprintf("base64 length of %d suggests missing '=' padding", len(input))
+	default:
+		return ""
+	}
+}
+
+// toAddressBytes aligns the given address in a 32 byte field according to the padding mode.
+// In strict mode, PaddingAuto only accepts addresses of exactly 32 bytes, or 20 bytes
+// for EVM domains, to not silently pad truncated inputs. Explicitly selected padding
+// modes resolve the ambiguity, so that they are accepted in strict mode as well.
+func toAddressBytes(opts Options, domain uint32, padding Padding, address []byte) ([]byte, error) {
+	switch padding {
+	case PaddingAuto, "":
+		if opts.Strict && len(address) != 32 {
+			d, found := LookupCCTPDomain(domain)
+			if !found || !d.EVM || len(address) != 20 {
+				return nil, fmt.Errorf(
+					"ambiguous address length of %d bytes; expected 32 bytes, or 20 bytes for EVM domains",
+					len(address),
+				)
+			}
+		}
+
+		return leftPadIfRequired(address)
+	case PaddingLeft:
+		return leftPadIfRequired(address)
+	case PaddingRight:
+		return rightPadIfRequired(address)
+	case PaddingNone:
+		if len(address) != 32 {
+			return nil, fmt.Errorf(
+				"address has %d bytes; expected exactly 32 bytes without padding",
+				len(address),
+			)
+		}
+
+		return address, nil
+	default:
+		return nil, fmt.Errorf("unknown padding %q", padding)
+	}
+}
+
+// leftPadIfRequired pads a byte slice to the left with 0x00 if the length is not 32 bytes.
+func leftPadIfRequired(input []byte) ([]byte, error) {
+	inputLen := len(input)
+	if inputLen > 32 {
+		return nil, fmt.Errorf("input is too long; max 32 bytes; got: %d", inputLen)
+	}
+
+	if inputLen == 32 {
+		return input, nil
+	}
+
+	padded := make([]byte, 32)
+	copy(padded[32-len(input):], input)
+
+	return padded, nil
+}
+
+// rightPadIfRequired pads a byte slice to the right with 0x00 if the length is not 32 bytes.
+func rightPadIfRequired(input []byte) ([]byte, error) {
+	inputLen := len(input)
+	if inputLen > 32 {
+		return nil, fmt.Errorf("input is too long; max 32 bytes; got: %d", inputLen)
+	}
+
+	padded := make([]byte, 32)
+	copy(padded, input)
+
+	return padded, nil
+}
+
+// maxPassthroughSize returns the maximum passthrough payload size in bytes
+// for the given destination domain. The on-chain limit in the options replaces
+// DefaultMaxPassthroughSize, so that it can also raise it, but it never exceeds
+// the known limit of the destination.
+func maxPassthroughSize(opts Options, domain uint32) uint32 {
+	var destLimit uint32
+	if d, found := LookupCCTPDomain(domain); found {
+		destLimit = d.MaxPassthroughSize
+	}
+
+	switch {
+	case opts.MaxPassthroughSize > 0 && destLimit > 0:
+		return min(opts.MaxPassthroughSize, destLimit)
+	case opts.MaxPassthroughSize > 0:
+		return opts.MaxPassthroughSize
+	case destLimit > 0:
+		return destLimit
+	default:
+		return DefaultMaxPassthroughSize
+	}
+}
@@ -0,0 +1,206 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// evmDomain is the CCTP domain of Ethereum, whose addresses have 20 bytes.
+const evmDomain = 0
+
+// errTooLong is the error message for addresses of 33 bytes.
+const errTooLong = "input is too long; max 32 bytes; got: 33"
+
+func TestDecodeAddress(t *testing.T) {
+	full := bytes.Repeat([]byte{0xab}, 32)
+	evm := bytes.Repeat([]byte{0x11}, 20)
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected []byte
+		errMsg   string
+	}{
+		{
+			name:     "valid 32 byte hex",
+			input:    hexutil.Encode(full),
+			expected: full,
+		},
+		{
+			name:     "short hex is left-padded",
+			input:    "0x0102",
+			expected: append(make([]byte, 30), 0x01, 0x02),
+		},
+		{
+			name:     "20 byte EVM address is left-padded",
+			input:    hexutil.Encode(evm),
+			expected: append(make([]byte, 12), evm...),
+		},
+		{
+			name:     "valid base64",
+			input:    base64.StdEncoding.EncodeToString(full),
+			expected: full,
+		},
+		{
+			name:   "over-length hex",
+			input:  hexutil.Encode(bytes.Repeat([]byte{0xab}, 33)),
+			errMsg: errTooLong,
+		},
+		{
+			name:   "over-length base64",
+			input:  base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xab}, 33)),
+			errMsg: errTooLong,
+		},
+		{
+			name:   "invalid hex",
+			input:  "0xzz",
+			errMsg: "failed to decode hex: invalid hex string",
+		},
+		{
+			name:   "invalid base64",
+			input:  "!!!!",
+			errMsg: "failed to decode base64: illegal base64 data at input byte 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := decodeAddress(Options{}, evmDomain, PaddingAuto, tc.input)
+			if tc.errMsg != "" {
+				require.EqualError(t, err, tc.errMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, decoded)
+		})
+	}
+}
+
+func TestLeftPadIfRequired(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  []byte
+		errMsg string
+	}{
+		{name: "empty", input: []byte{}},
+		{name: "20 bytes", input: bytes.Repeat([]byte{0x11}, 20)},
+		{name: "32 bytes", input: bytes.Repeat([]byte{0x22}, 32)},
+		{
+			name:   "33 bytes",
+			input:  bytes.Repeat([]byte{0x33}, 33),
+			errMsg: errTooLong,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			padded, err := leftPadIfRequired(tc.input)
+			if tc.errMsg != "" {
+				require.EqualError(t, err, tc.errMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, padded, 32)
+			require.Equal(t, make([]byte, 32-len(tc.input)), padded[:32-len(tc.input)])
+			require.Equal(t, tc.input, padded[32-len(tc.input):])
+
+			// Decoding the hex encoding of the padded address returns it unchanged.
+			decoded, err := decodeAddress(Options{}, evmDomain, PaddingAuto, hexutil.Encode(padded))
+			require.NoError(t, err)
+			require.Equal(t, padded, decoded)
+		})
+	}
+}
+
+func TestNewCCTPForwardingMixedEncodings(t *testing.T) {
+	recipient := bytes.Repeat([]byte{0x42}, 32)
+	bech32Recipient, err := bech32.ConvertAndEncode("osmo", recipient)
+	require.NoError(t, err)
+
+	caller := bytes.Repeat([]byte{0x11}, 20)
+
+	// NOTE: the recipient is on a non-EVM domain, while the caller is an EVM-style address.
+	fwd, err := NewCCTPForwarding(Options{}, 5, bech32Recipient, hexutil.Encode(caller), "")
+	require.NoError(t, err)
+
+	attr, err := fwd.CachedAttributes()
+	require.NoError(t, err)
+
+	cctp, ok := attr.(*forwarding.CCTPAttributes)
+	require.True(t, ok, "expected CCTP attributes; got: %T", attr)
+	require.Equal(t, uint32(5), cctp.DestinationDomain)
+	require.Equal(t, recipient, cctp.MintRecipient)
+	require.Equal(t, append(make([]byte, 12), caller...), cctp.DestinationCaller)
+}
+
+func TestMaxPassthroughSize(t *testing.T) {
+	const limited = 10_000
+	CCTPDomains = append(
+		CCTPDomains,
+		CCTPDomain{ID: limited, Name: "Limited", MaxPassthroughSize: 100},
+	)
+	t.Cleanup(func() { CCTPDomains = CCTPDomains[:len(CCTPDomains)-1] })
+
+	tcs := []struct {
+		name     string
+		limit    uint32
+		domain   uint32
+		expected uint32
+	}{
+		{name: "default for unknown limit", domain: evmDomain, expected: DefaultMaxPassthroughSize},
+		{name: "configured limit lowers the default", limit: 10, domain: evmDomain, expected: 10},
+		{
+			name:     "configured limit raises the default",
+			limit:    2 * DefaultMaxPassthroughSize,
+			domain:   evmDomain,
+			expected: 2 * DefaultMaxPassthroughSize,
+		},
+		{name: "destination limit", domain: limited, expected: 100},
+		{name: "configured limit below destination limit", limit: 10, domain: limited, expected: 10},
+		{name: "destination limit caps configured limit", limit: 1_000, domain: limited, expected: 100},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := Options{MaxPassthroughSize: tc.limit}
+			require.Equal(t, tc.expected, maxPassthroughSize(opts, tc.domain))
+		})
+	}
+}
+
+func TestNewCCTPForwardingPassthroughTooLong(t *testing.T) {
+	opts := Options{MaxPassthroughSize: 4}
+	recipient := "0x0000000000000000000000000000000000000001"
+
+	_, err := NewCCTPForwarding(opts, 6, recipient, "", "12345")
+	require.ErrorIs(t, err, ErrPassthroughTooLong)
+	require.ErrorContains(t, err, "too long for Base; max 4 bytes; got: 5")
+}
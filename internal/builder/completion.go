@@ -0,0 +1,299 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+)
+
+// CompletionFlag describes a command line flag to be completed.
+type CompletionFlag struct {
+	Name   string
+	Usage  string
+	IsBool bool
+}
+
+// completionCommand is a positional command of the CLI.
+type completionCommand struct {
+	name, desc string
+}
+
+// completionCommands contains the positional commands, that are completed as first argument.
+var completionCommands = []completionCommand{
+	{name: "cctp", desc: "generate a CCTP forwarding payload"},
+	{name: "internal", desc: "generate an internal forwarding payload"},
+	{name: "validate", desc: "validate a spec file without generating a payload"},
+	{name: "recent", desc: "list, print or edit recently generated payloads"},
+	{name: "doctor", desc: "check the environment for common problems"},
+	{name: "completion", desc: "print a shell completion script"},
+}
+
+// completionShells contains the shells, for which completion scripts can be generated.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// CompletionScript returns the completion script for the given shell,
+// which completes the given flags, the positional commands and the known CCTP domains.
+func CompletionScript(shell string, flags []CompletionFlag) (string, error) {
+	switch shell {
+	case "bash":
+		return bashCompletion(flags), nil
+	case "zsh":
+		return zshCompletion(flags), nil
+	case "fish":
+		return fishCompletion(flags), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q; expected one of %v", shell, completionShells)
+	}
+}
+
+// flagValues returns the known values of the flag with the given name,
+// or nil if the values cannot be enumerated.
+func flagValues(name string) []string {
+	switch name {
+	case "format":
+		values := make([]string, 0, len(Formats))
+		for _, f := range Formats {
+			values = append(values, string(f))
+		}
+
+		return values
+	case "keyring-backend":
+		return []string{"os", "test"}
+	default:
+		return nil
+	}
+}
+
+// destinationDomains returns the known CCTP domains, that are valid destinations.
+func destinationDomains() []CCTPDomain {
+	domains := make([]CCTPDomain, 0, len(CCTPDomains))
+	for _, d := range CCTPDomains {
+		if d.ID != forwarding.CCTPNobleDomain {
+			domains = append(domains, d)
+		}
+	}
+
+	return domains
+}
+
+func domainIDs() []string {
+	domains := destinationDomains()
+
+	ids := make([]string, 0, len(domains))
+	for _, d := range domains {
+		ids = append(ids, strconv.FormatUint(uint64(d.ID), 10))
+	}
+
+	return ids
+}
+
+func bashCompletion(flags []CompletionFlag) string {
+	var (
+		s        strings.Builder
+		names    = make([]string, 0, len(flags))
+		commands = make([]string, 0, len(completionCommands))
+	)
+	for _, f := range flags {
+		names = append(names, "--"+f.Name)
+	}
+	for _, c := range completionCommands {
+		commands = append(commands, c.name)
+	}
+
+	s.WriteString("# bash completion for orbgen\n")
+	s.WriteString("_orbgen() {\n")
+	s.WriteString(
+		"\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n",
+	)
+	s.WriteString("\tcase \"$prev\" in\n")
+	for _, f := range flags {
+		if values := flagValues(f.Name); values != nil {
+			fmt.Fprintf(&s, "\t--%s | -%s)\n", f.Name, f.Name)
+			fmt.Fprintf(
+				&s,
+				"\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n",
+				strings.Join(values, " "),
+			)
+			s.WriteString("\t\treturn\n\t\t;;\n")
+		}
+	}
+	if valueFlags := bashValueFlags(flags); valueFlags != "" {
+		// NOTE: returning without completions falls back to the default file completion.
+		fmt.Fprintf(&s, "\t%s)\n\t\treturn\n\t\t;;\n", valueFlags)
+	}
+	s.WriteString("\tcctp)\n")
+	fmt.Fprintf(
+		&s,
+		"\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n",
+		strings.Join(domainIDs(), " "),
+	)
+	s.WriteString("\t\treturn\n\t\t;;\n")
+	s.WriteString("\tcompletion)\n")
+	fmt.Fprintf(
+		&s,
+		"\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n",
+		strings.Join(completionShells, " "),
+	)
+	s.WriteString("\t\treturn\n\t\t;;\n")
+	s.WriteString("\tesac\n\n")
+	s.WriteString("\tif [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(&s, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(names, " "))
+	s.WriteString("\t\treturn\n\tfi\n\n")
+	s.WriteString("\tlocal word\n")
+	s.WriteString("\tfor word in \"${COMP_WORDS[@]:1:COMP_CWORD-1}\"; do\n")
+	fmt.Fprintf(&s, "\t\tcase \"$word\" in %s) return ;; esac\n", strings.Join(commands, " | "))
+	s.WriteString("\tdone\n")
+	fmt.Fprintf(&s, "\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(commands, " "))
+	s.WriteString("}\n\n")
+	s.WriteString("complete -o default -F _orbgen orbgen\n")
+
+	return s.String()
+}
+
+// bashValueFlags returns the case pattern matching all flags,
+// which take a value that cannot be enumerated.
+func bashValueFlags(flags []CompletionFlag) string {
+	patterns := make([]string, 0, len(flags))
+	for _, f := range flags {
+		if !f.IsBool && flagValues(f.Name) == nil {
+			patterns = append(patterns, "--"+f.Name, "-"+f.Name)
+		}
+	}
+
+	return strings.Join(patterns, " | ")
+}
+
+func zshCompletion(flags []CompletionFlag) string {
+	var s strings.Builder
+
+	s.WriteString("#compdef orbgen\n\n")
+	s.WriteString("_orbgen() {\n")
+	s.WriteString("\tlocal -a commands domains\n")
+	s.WriteString("\tcommands=(\n")
+	for _, c := range completionCommands {
+		fmt.Fprintf(&s, "\t\t'%s:%s'\n", c.name, zshEscape(c.desc))
+	}
+	s.WriteString("\t)\n")
+	s.WriteString("\tdomains=(\n")
+	for _, d := range destinationDomains() {
+		fmt.Fprintf(&s, "\t\t'%d:%s'\n", d.ID, zshEscape(d.Name))
+	}
+	s.WriteString("\t)\n\n")
+	s.WriteString("\t_arguments -s \\\n")
+	for _, f := range flags {
+		desc := zshEscape(f.Usage)
+		switch {
+		case f.IsBool:
+			fmt.Fprintf(&s, "\t\t'--%s[%s]' \\\n", f.Name, desc)
+		case flagValues(f.Name) != nil:
+			fmt.Fprintf(
+				&s,
+				"\t\t'--%s=[%s]:%s:(%s)' \\\n",
+				f.Name,
+				desc,
+				f.Name,
+				strings.Join(flagValues(f.Name), " "),
+			)
+		default:
+			fmt.Fprintf(&s, "\t\t'--%s=[%s]:%s:_files' \\\n", f.Name, desc, f.Name)
+		}
+	}
+	s.WriteString("\t\t'*::arg:->args'\n\n")
+	s.WriteString("\tcase $state in\n")
+	s.WriteString("\targs)\n")
+	s.WriteString("\t\tcase $words[1] in\n")
+	s.WriteString("\t\tcctp) (( CURRENT == 2 )) && _describe 'domain' domains ;;\n")
+	fmt.Fprintf(
+		&s,
+		"\t\tcompletion) (( CURRENT == 2 )) && _values 'shell' %s ;;\n",
+		strings.Join(completionShells, " "),
+	)
+	s.WriteString("\t\t*) (( CURRENT == 1 )) && _describe 'command' commands ;;\n")
+	s.WriteString("\t\tesac\n")
+	s.WriteString("\t\t;;\n")
+	s.WriteString("\tesac\n")
+	s.WriteString("}\n\n")
+	s.WriteString("_orbgen \"$@\"\n")
+
+	return s.String()
+}
+
+func fishCompletion(flags []CompletionFlag) string {
+	var (
+		s        strings.Builder
+		commands = make([]string, 0, len(completionCommands))
+	)
+	for _, c := range completionCommands {
+		commands = append(commands, c.name)
+	}
+
+	s.WriteString("# fish completion for orbgen\n")
+	s.WriteString("complete -c orbgen -f\n")
+	for _, f := range flags {
+		line := fmt.Sprintf("complete -c orbgen -l %s -d %s", f.Name, fishQuote(f.Usage))
+		if values := flagValues(f.Name); values != nil {
+			line += fmt.Sprintf(" -x -a %s", fishQuote(strings.Join(values, " ")))
+		} else if !f.IsBool {
+			line += " -r -F"
+		}
+		s.WriteString(line + "\n")
+	}
+
+	noCommand := "not __fish_seen_subcommand_from " + strings.Join(commands, " ")
+	for _, c := range completionCommands {
+		fmt.Fprintf(&s, "complete -c orbgen -n %s -a %s -d %s\n",
+			fishQuote(noCommand), c.name, fishQuote(c.desc))
+	}
+
+	for _, d := range destinationDomains() {
+		fmt.Fprintf(
+			&s,
+			"complete -c orbgen -n %s -a %d -d %s\n",
+			fishQuote("__fish_seen_subcommand_from cctp; and test (count (commandline -opc)) -eq 2"),
+			d.ID,
+			fishQuote(d.Name),
+		)
+	}
+
+	for _, shell := range completionShells {
+		fmt.Fprintf(&s, "complete -c orbgen -n %s -a %s\n",
+			fishQuote("__fish_seen_subcommand_from completion"), shell)
+	}
+
+	return s.String()
+}
+
+// zshEscape escapes the characters with a special meaning in zsh completion specs,
+// for use in a single-quoted string.
+func zshEscape(s string) string {
+	return strings.NewReplacer(
+		"'", `'\''`,
+		"[", `\[`,
+		"]", `\]`,
+		":", `\:`,
+	).Replace(s)
+}
+
+// fishQuote returns the given string as a single-quoted fish string.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
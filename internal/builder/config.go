@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Config contains the user preferences, that are persisted between runs
+// in the user's config directory.
+type Config struct {
+	// OutputFormat is the output format, that was last selected in the TUI.
+	OutputFormat Format `json:"output_format,omitempty"`
+	// DefaultDestinationCallers contains the destination callers by CCTP domain,
+	// that are prefilled in the TUI when selecting the domain.
+	DefaultDestinationCallers map[uint32]string `json:"default_destination_callers,omitempty"`
+	// KnownCallers contains the named destination callers by CCTP domain,
+	// which can be entered by name instead of their address.
+	KnownCallers map[uint32][]KnownCaller `json:"known_callers,omitempty"`
+}
+
+// ConfigPath returns the path of the config file.
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	return filepath.Join(dir, "orbgen", "config.json"), nil
+}
+
+// LoadConfig reads the persisted config.
+// The default config is returned if no config was persisted yet.
+func LoadConfig() (Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return Config{}, err
+	}
+
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	} else if err != nil {
+		return Config{}, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var cfg Config
+	if err = json.Unmarshal(bz, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// SaveConfig persists the given config.
+func SaveConfig(cfg Config) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err = os.WriteFile(path, bz, 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
+}
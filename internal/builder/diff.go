@@ -0,0 +1,115 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/noble-assets/orbiter/types"
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// DiffPayloads returns a field-level diff of the two given payloads, with one line per
+// differing field. Fields only present in the first payload are prefixed with "-",
+// fields only present in the second one with "+", and changed fields with "~".
+// An empty diff means that both payloads are equal.
+func DiffPayloads(a, b *core.PayloadWrapper) ([]string, error) {
+	fieldsA, err := flattenPayload(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten first payload: %w", err)
+	}
+
+	fieldsB, err := flattenPayload(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten second payload: %w", err)
+	}
+
+	paths := make([]string, 0, len(fieldsA)+len(fieldsB))
+	for path := range fieldsA {
+		paths = append(paths, path)
+	}
+	for path := range fieldsB {
+		if _, found := fieldsA[path]; !found {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var diff []string
+	for _, path := range paths {
+		valueA, inA := fieldsA[path]
+		valueB, inB := fieldsB[path]
+
+		switch {
+		case !inB:
+			diff = append(diff, fmt.Sprintf("- %s: %s", path, valueA))
+		case !inA:
+			diff = append(diff, fmt.Sprintf("+ %s: %s", path, valueB))
+		case valueA != valueB:
+			diff = append(diff, fmt.Sprintf("~ %s: %s -> %s", path, valueA, valueB))
+		}
+	}
+
+	return diff, nil
+}
+
+// flattenPayload returns all fields of the payload's JSON representation
+// mapped from their path (e.g. "orbiter.forwarding.protocol_id") to their value.
+func flattenPayload(payload *core.PayloadWrapper) (map[string]string, error) {
+	bz, err := types.MarshalJSON(newCodec(), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	var decoded any
+	if err = json.Unmarshal(bz, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	fields := make(map[string]string)
+	flattenValue("", decoded, fields)
+
+	return fields, nil
+}
+
+func flattenValue(path string, value any, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if path == "" {
+				flattenValue(key, nested, fields)
+			} else {
+				flattenValue(path+"."+key, nested, fields)
+			}
+		}
+	case []any:
+		for i, nested := range v {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), nested, fields)
+		}
+	default:
+		bz, err := json.Marshal(v)
+		if err != nil {
+			// NOTE: this can not happen for values decoded from JSON.
+			panic(err)
+		}
+
+		fields[path] = string(bz)
+	}
+}
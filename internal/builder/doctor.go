@@ -0,0 +1,175 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/noble-assets/orbiter/testutil"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// orbiterModule is the module path of the Orbiter types used to build the payloads.
+const orbiterModule = "github.com/noble-assets/orbiter"
+
+// clipboardTools contains the commands, of which one is required
+// to paste from the clipboard on Linux and BSD systems.
+var clipboardTools = []string{"xclip", "xsel", "wl-paste", "termux-clipboard-get"}
+
+// DoctorCheck is the outcome of a single check of the environment.
+type DoctorCheck struct {
+	Name string `json:"name"`
+	OK   bool   `json:"ok"`
+	// Detail describes the checked value, or why the check failed.
+	Detail string `json:"detail"`
+}
+
+// RunDoctor checks the environment, that orbgen is running in.
+// The checks only inspect the local environment, so no network access is required.
+func RunDoctor() []DoctorCheck {
+	return []DoctorCheck{
+		checkBech32Prefix(),
+		checkOrbiterVersion(),
+		checkClipboard(),
+		checkTerminal("stdin", os.Stdin),
+		checkTerminal("stdout", os.Stdout),
+		checkConfig(),
+	}
+}
+
+// checkBech32Prefix checks that the SDK config uses the Noble prefix,
+// which is required to validate Noble addresses.
+func checkBech32Prefix() DoctorCheck {
+	prefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
+	check := DoctorCheck{Name: "bech32 prefix", OK: prefix == testutil.Prefix, Detail: prefix}
+	if !check.OK {
+		check.Detail = fmt.Sprintf("expected %s; got %s", testutil.Prefix, prefix)
+	}
+
+	return check
+}
+
+// checkOrbiterVersion reports the version of the Orbiter module, that orbgen was built with.
+func checkOrbiterVersion() DoctorCheck {
+	check := DoctorCheck{Name: "orbiter version"}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		check.Detail = "build info not available"
+
+		return check
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != orbiterModule {
+			continue
+		}
+
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+
+		check.OK = true
+		check.Detail = dep.Version
+
+		return check
+	}
+
+	check.Detail = orbiterModule + " not found in build info"
+
+	return check
+}
+
+// checkClipboard checks that pasting from the clipboard into inputs is supported,
+// which requires one of the clipboard tools on systems other than macOS and Windows.
+func checkClipboard() DoctorCheck {
+	check := DoctorCheck{Name: "clipboard"}
+
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		check.OK = true
+		check.Detail = "supported on " + runtime.GOOS
+
+		return check
+	}
+
+	for _, tool := range clipboardTools {
+		if path, err := exec.LookPath(tool); err == nil {
+			check.OK = true
+			check.Detail = path
+
+			return check
+		}
+	}
+
+	check.Detail = fmt.Sprintf(
+		"none of %v found; pasting with Ctrl+V is not available",
+		clipboardTools,
+	)
+
+	return check
+}
+
+// checkTerminal checks whether the given file is a terminal,
+// which is required for the interactive mode.
+func checkTerminal(name string, f *os.File) DoctorCheck {
+	check := DoctorCheck{Name: name + " is a terminal"}
+
+	info, err := f.Stat()
+	if err != nil {
+		check.Detail = err.Error()
+
+		return check
+	}
+
+	check.OK = info.Mode()&os.ModeCharDevice != 0
+	check.Detail = "yes"
+	if !check.OK {
+		check.Detail = "no; the TUI requires an interactive terminal"
+	}
+
+	return check
+}
+
+// checkConfig checks that the persisted config can be read.
+func checkConfig() DoctorCheck {
+	check := DoctorCheck{Name: "config"}
+
+	path, err := ConfigPath()
+	if err != nil {
+		check.Detail = err.Error()
+
+		return check
+	}
+
+	if _, err = LoadConfig(); err != nil {
+		check.Detail = err.Error()
+
+		return check
+	}
+
+	check.OK = true
+	check.Detail = path
+
+	return check
+}
@@ -0,0 +1,184 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CCTPDomain contains the information about a CCTP domain.
+type CCTPDomain struct {
+	ID   uint32
+	Name string
+	// EVM is true for domains using 20 byte EVM addresses.
+	EVM bool
+	// Bech32Prefix is the address prefix of Cosmos-based domains,
+	// whose addresses are conventionally entered in bech32.
+	Bech32Prefix string
+	// MaxPassthroughSize is the maximum size of the passthrough payload in bytes,
+	// that is accepted by the destination. Zero if the limit is unknown.
+	MaxPassthroughSize uint32
+	// DefaultDestinationCaller is the destination caller, that is conventionally used
+	// on the domain. It is prefilled in the TUI when selecting the domain, if set.
+	DefaultDestinationCaller string
+}
+
+// KnownCaller is a named destination caller on a CCTP domain, i.e. a relayer
+// or integrator contract, that calls receiveMessage on the message transmitter.
+// Known callers are defined by the user in the config or a profile.
+type KnownCaller struct {
+	// Name is the name to enter instead of the address. It contains a '-',
+	// so that it cannot be mistaken for a base64 encoded address.
+	Name string `json:"name"`
+	// Address is the hex, bech32 or base64 encoded address of the caller.
+	Address string `json:"address"`
+}
+
+// CCTPDomains contains the known CCTP domains.
+//
+// NOTE: no destination limits for passthrough payloads are recorded yet, so that
+// DefaultMaxPassthroughSize applies to all domains, unless a limit is configured.
+//
+// NOTE: no default destination callers are recorded, but they can be configured by the user.
+// Likewise, the known callers are only defined by the user, because the callers depend
+// on the relayer used. Only contracts, that call receiveMessage for a CCTP V1 message,
+// qualify, which the message transmitter itself never does.
+var CCTPDomains = []CCTPDomain{
+	{ID: 0, Name: "Ethereum", EVM: true},
+	{ID: 1, Name: "Avalanche", EVM: true},
+	{ID: 2, Name: "OP Mainnet", EVM: true},
+	{ID: 3, Name: "Arbitrum", EVM: true},
+	{ID: 4, Name: "Noble", Bech32Prefix: "noble"},
+	{ID: 5, Name: "Solana"},
+	{ID: 6, Name: "Base", EVM: true},
+	{ID: 7, Name: "Polygon PoS", EVM: true},
+	{ID: 8, Name: "Sui"},
+	{ID: 9, Name: "Aptos"},
+	{ID: 10, Name: "Unichain", EVM: true},
+}
+
+// LookupCCTPDomain returns the known CCTP domain with the given ID.
+func LookupCCTPDomain(id uint32) (CCTPDomain, bool) {
+	for _, d := range CCTPDomains {
+		if d.ID == id {
+			return d, true
+		}
+	}
+
+	return CCTPDomain{}, false
+}
+
+// CCTPDomainName returns the name of the CCTP domain with the given ID.
+func CCTPDomainName(id uint32) string {
+	if d, found := LookupCCTPDomain(id); found {
+		return d.Name
+	}
+
+	return "unknown domain"
+}
+
+// LookupKnownCaller returns the known destination caller with the given name on the domain.
+func LookupKnownCaller(opts Options, domain uint32, name string) (KnownCaller, bool) {
+	for _, caller := range opts.KnownCallers[domain] {
+		if strings.EqualFold(caller.Name, name) {
+			return caller, true
+		}
+	}
+
+	return KnownCaller{}, false
+}
+
+// KnownCallerName returns the name of the known destination caller on the domain,
+// whose 32 byte address equals the given one, or an empty string if none matches.
+func KnownCallerName(opts Options, domain uint32, address []byte) string {
+	for _, caller := range opts.KnownCallers[domain] {
+		bz, err := knownCallerAddress(caller)
+		if err == nil && bytes.Equal(bz, address) {
+			return caller.Name
+		}
+	}
+
+	return ""
+}
+
+// MergeKnownCallers returns the known callers of the config, extended by the ones
+// of the profile, which replace callers of the config with the same name.
+// The names and addresses of all callers are validated.
+func MergeKnownCallers(config, profile map[uint32][]KnownCaller) (map[uint32][]KnownCaller, error) {
+	merged := make(map[uint32][]KnownCaller, len(config)+len(profile))
+	for _, source := range []map[uint32][]KnownCaller{config, profile} {
+		for domain, callers := range source {
+			for _, caller := range callers {
+				if err := validateKnownCaller(caller); err != nil {
+					return nil, fmt.Errorf("invalid known caller on domain %d: %w", domain, err)
+				}
+
+				merged[domain] = slices.DeleteFunc(merged[domain], func(c KnownCaller) bool {
+					return strings.EqualFold(c.Name, caller.Name)
+				})
+				merged[domain] = append(merged[domain], caller)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// validateKnownCaller checks that the name of the caller cannot be mistaken
+// for an address and that its address is valid.
+func validateKnownCaller(caller KnownCaller) error {
+	if !strings.Contains(caller.Name, "-") {
+		return fmt.Errorf(
+			"name %q has to contain a '-', to not be mistaken for a base64 address",
+			caller.Name,
+		)
+	}
+
+	if _, err := knownCallerAddress(caller); err != nil {
+		return fmt.Errorf("address of %s: %w", caller.Name, err)
+	}
+
+	return nil
+}
+
+// knownCallerAddress returns the address of the known caller, left-padded to 32 bytes.
+func knownCallerAddress(caller KnownCaller) ([]byte, error) {
+	bz, err := decodeAddressBytes(caller.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return leftPadIfRequired(bz)
+}
+
+// DefaultDestinationCaller returns the destination caller, that is prefilled for the given domain.
+// Defaults configured in the options take precedence over the ones of the known domains.
+func DefaultDestinationCaller(opts Options, domain uint32) string {
+	if caller, found := opts.DefaultDestinationCallers[domain]; found {
+		return caller
+	}
+
+	if d, found := LookupCCTPDomain(domain); found {
+		return d.DefaultDestinationCaller
+	}
+
+	return ""
+}
@@ -0,0 +1,59 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// endpointTimeout is the timeout for connecting to a unix socket.
+const endpointTimeout = 10 * time.Second
+
+// WriteToEndpoint writes the given output to the unix socket or named pipe at the given path.
+func WriteToEndpoint(path, output string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to access output endpoint: %w", err)
+	}
+
+	var w io.WriteCloser
+	switch mode := info.Mode(); {
+	case mode&os.ModeSocket != 0:
+		w, err = net.DialTimeout("unix", path, endpointTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to connect to output socket: %w", err)
+		}
+	case mode&os.ModeNamedPipe != 0:
+		// NOTE: opening a named pipe blocks until it is opened for reading.
+		w, err = os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("failed to open output pipe: %w", err)
+		}
+	default:
+		return fmt.Errorf("output endpoint %s is neither a unix socket nor a named pipe", path)
+	}
+
+	_, err = io.WriteString(w, output+"\n")
+
+	return errors.Join(err, w.Close())
+}
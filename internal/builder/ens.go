@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ensRegistry is the address of the ENS registry on Ethereum mainnet.
+var ensRegistry = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+// ensTimeout is the maximum duration of resolving a single ENS name.
+const ensTimeout = 10 * time.Second
+
+// IsENSName returns true if the given input looks like an ENS name (e.g. "vitalik.eth").
+func IsENSName(input string) bool {
+	return !strings.HasPrefix(input, "0x") && strings.HasSuffix(strings.ToLower(input), ".eth")
+}
+
+// resolveENSName resolves the given ENS name to the 20 byte address it points to,
+// by querying the ENS registry and the name's resolver through the given RPC endpoint.
+func resolveENSName(rpcURL, name string) ([]byte, error) {
+	if rpcURL == "" {
+		return nil, errors.New(
+			"resolving ENS names requires an RPC endpoint to be set via --ens-rpc",
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ensTimeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ENS RPC: %w", err)
+	}
+	defer client.Close()
+
+	node := ensNamehash(name)
+
+	resolver, err := callENS(ctx, client, ensRegistry, "resolver(bytes32)", node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resolver of %s: %w", name, err)
+	}
+	if resolver == (common.Address{}) {
+		return nil, fmt.Errorf("no resolver set for %s", name)
+	}
+
+	addr, err := callENS(ctx, client, resolver, "addr(bytes32)", node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve address of %s: %w", name, err)
+	}
+	if addr == (common.Address{}) {
+		return nil, fmt.Errorf("no address set for %s", name)
+	}
+
+	return addr.Bytes(), nil
+}
+
+// ResolveENSAddress resolves the given ENS name to the checksummed hex address it points to,
+// through the ENS RPC endpoint of the given options.
+func ResolveENSAddress(opts Options, name string) (string, error) {
+	addr, err := resolveENSName(opts.ENSRPC, name)
+	if err != nil {
+		return "", err
+	}
+
+	return common.BytesToAddress(addr).Hex(), nil
+}
+
+// callENS calls the given ENS contract method, which takes a node hash
+// as its only argument and returns an address.
+func callENS(
+	ctx context.Context,
+	client *ethclient.Client,
+	contract common.Address,
+	method string,
+	node common.Hash,
+) (common.Address, error) {
+	data := append(crypto.Keccak256([]byte(method))[:4], node.Bytes()...)
+
+	res, err := client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(res) != 32 {
+		return common.Address{}, fmt.Errorf("unexpected response length: %d", len(res))
+	}
+
+	return common.BytesToAddress(res), nil
+}
+
+// ensNamehash computes the ENS namehash of the given name as defined in EIP-137.
+func ensNamehash(name string) common.Hash {
+	var node common.Hash
+
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+
+	return node
+}
@@ -0,0 +1,75 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEmptyRecipient     = errors.New("empty recipient")
+	ErrInvalidRecipient   = errors.New("invalid recipient")
+	ErrInvalidBasisPoints = errors.New("invalid basis points")
+	ErrBPSOutOfRange      = errors.New("basis points out of range")
+	ErrFeesExceedAmount   = errors.New("total fees exceed the transferred amount")
+	ErrInvalidDomain      = errors.New("invalid destination domain")
+	ErrInvalidAddress     = errors.New("invalid address")
+	ErrZeroRecipient      = errors.New("zero mint recipient")
+	ErrInvalidPassthrough = errors.New("invalid passthrough payload")
+	ErrPassthroughTooLong = errors.New("passthrough payload too long")
+	ErrNotSupported       = errors.New("not supported")
+	ErrMissingForwarding  = errors.New(
+		"payload requires a forwarding; actions-only payloads are not supported",
+	)
+)
+
+// Names of the input fields, that errors can pertain to.
+const (
+	FieldFeeRecipient      = "fee recipient"
+	FieldBasisPoints       = "basis points"
+	FieldDestinationDomain = "destination domain"
+	FieldMintRecipient     = "mint recipient"
+	FieldDestinationCaller = "destination caller"
+	FieldPassthrough       = "passthrough payload"
+	FieldRecipient         = "recipient"
+)
+
+// Error is returned by the builder functions. It keeps the human-readable message,
+// while matching the corresponding sentinel error through errors.Is and exposing
+// the offending input field through errors.As.
+type Error struct {
+	// Field is the name of the input field the error pertains to.
+	Field string
+
+	kind error
+	err  error
+}
+
+// newError returns a builder error for the given field, that matches the kind of error.
+// The message is formatted like fmt.Errorf, so underlying errors can be wrapped with %w.
+func newError(kind error, field, format string, args ...any) *Error {
+	return &Error{
+		Field: field,
+		kind:  kind,
+		err:   fmt.Errorf(format, args...),
+	}
+}
+
+func (e *Error) Error() string   { return e.err.Error() }
+func (e *Error) Unwrap() []error { return []error{e.kind, e.err} }
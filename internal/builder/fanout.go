@@ -0,0 +1,74 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// FanOutResult is the outcome of generating the payload for a single mint recipient.
+type FanOutResult struct {
+	// Line is the line number of the recipient in the input, starting at 1.
+	Line      int
+	Recipient string
+	Payload   string
+	Err       error
+}
+
+// FanOut generates one payload per mint recipient read from the given input,
+// which are otherwise equal to the CCTP forwarding described by the spec.
+//
+// The input contains one recipient per line in any supported encoding.
+// Empty lines and lines starting with '#' are skipped. Invalid recipients
+// are reported in the results passed to the callback, without aborting the run.
+// The results are passed to the callback in input order as soon as they are generated,
+// so that inputs of any size are processed with constant memory.
+func (s Spec) FanOut(opts Options, r io.Reader, fn func(FanOutResult)) error {
+	if s.Forwarding.Protocol != core.PROTOCOL_CCTP.String() || s.Forwarding.CCTP == nil {
+		return errors.New("generating payloads for multiple recipients requires a CCTP forwarding")
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		recipient := strings.TrimSpace(scanner.Text())
+		if recipient == "" || strings.HasPrefix(recipient, "#") {
+			continue
+		}
+
+		cctp := *s.Forwarding.CCTP
+		cctp.MintRecipient = recipient
+
+		spec := s
+		spec.Forwarding.CCTP = &cctp
+
+		payload, err := spec.Build(opts)
+		fn(FanOutResult{Line: line, Recipient: recipient, Payload: payload, Err: err})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read recipients: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/controller/action"
+	"github.com/noble-assets/orbiter/types/core"
+
+	sdkmath "cosmossdk.io/math"
+)
+
+// ParseBasisPoints parses the given input as basis points. Inputs with a '%' suffix
+// are interpreted as a percentage and converted to basis points (e.g. 1.5% = 150).
+//
+// Percentages, that are not a whole number of basis points (e.g. 0.335%), are rounded
+// to the nearest basis point, with halves rounded up (e.g. 0.335% = 34). In strict mode,
+// such percentages are rejected instead.
+func ParseBasisPoints(opts Options, input string) (uint32, error) {
+	if input == "" {
+		return 0, newError(ErrInvalidBasisPoints, FieldBasisPoints, "basis points is required")
+	}
+
+	percentage, isPercentage := strings.CutSuffix(input, "%")
+	if !isPercentage {
+		basisPoints, err := strconv.ParseUint(input, 10, 32)
+		if err != nil {
+			return 0, newError(
+				ErrInvalidBasisPoints,
+				FieldBasisPoints,
+				"invalid basis points: %w",
+				err,
+			)
+		}
+
+		return uint32(basisPoints), nil
+	}
+
+	basisPoints, err := PercentageToBasisPoints(percentage)
+	if err != nil {
+		return 0, err
+	}
+
+	if opts.Strict && !basisPoints.IsInteger() {
+		return 0, newError(
+			ErrInvalidBasisPoints,
+			FieldBasisPoints,
+			"percentage %s is not a whole number of basis points",
+			input,
+		)
+	}
+
+	bpsInt := basisPoints.Add(sdkmath.LegacyNewDecWithPrec(5, 1)).TruncateInt()
+	if !bpsInt.IsUint64() || bpsInt.Uint64() > math.MaxUint32 {
+		return 0, newError(
+			ErrBPSOutOfRange,
+			FieldBasisPoints,
+			"percentage %s is out of range",
+			input,
+		)
+	}
+
+	return uint32(bpsInt.Uint64()), nil
+}
+
+// PercentageToBasisPoints converts the given percentage without '%' suffix
+// into the exact, possibly fractional, number of basis points.
+func PercentageToBasisPoints(percentage string) (sdkmath.LegacyDec, error) {
+	dec, err := sdkmath.LegacyNewDecFromStr(strings.TrimSpace(percentage))
+	if err != nil {
+		return sdkmath.LegacyDec{}, newError(
+			ErrInvalidBasisPoints,
+			FieldBasisPoints,
+			"invalid percentage: %w",
+			err,
+		)
+	}
+
+	if dec.IsNegative() {
+		return sdkmath.LegacyDec{}, newError(
+			ErrBPSOutOfRange,
+			FieldBasisPoints,
+			"percentage cannot be negative; got: %s%%",
+			percentage,
+		)
+	}
+
+	return dec.MulInt64(100), nil
+}
+
+// ParseFee parses a fee given as <recipient>:<basis-points> in a single string,
+// where the basis points can also be given as a percentage (e.g. noble1...:1%).
+func ParseFee(opts Options, entry string) (FeeSpec, error) {
+	recipient, bps, found := strings.Cut(entry, ":")
+	if !found {
+		return FeeSpec{}, fmt.Errorf(
+			"fee %q has to be given as <recipient>:<basis-points>",
+			entry,
+		)
+	}
+
+	basisPoints, err := ParseBasisPoints(opts, bps)
+	if err != nil {
+		return FeeSpec{}, err
+	}
+
+	return FeeSpec{Recipient: recipient, BasisPoints: basisPoints}, nil
+}
+
+// NewFeeAction creates a validated fee action, that pays the given
+// basis points of the transferred amount to the recipient.
+func NewFeeAction(recipient string, basisPoints uint32) (*core.Action, error) {
+	if recipient == "" {
+		return nil, newError(ErrEmptyRecipient, FieldFeeRecipient, "recipient address is required")
+	}
+
+	info := action.FeeInfo{
+		Recipient:   recipient,
+		BasisPoints: basisPoints,
+	}
+
+	// NOTE: The basis points are checked separately from the orbiter validation,
+	// to be able to tell which of the inputs is invalid.
+	if basisPoints == 0 || basisPoints > action.BPSNormalizer {
+		return nil, newError(
+			ErrBPSOutOfRange,
+			FieldBasisPoints,
+			"invalid fee attributes: %w",
+			info.Validate(),
+		)
+	}
+
+	feeAttr := action.FeeAttributes{
+		FeesInfo: []*action.FeeInfo{&info},
+	}
+
+	if err := feeAttr.Validate(); err != nil {
+		return nil, newError(
+			ErrInvalidRecipient,
+			FieldFeeRecipient,
+			"invalid fee attributes: %w",
+			err,
+		)
+	}
+
+	feeAction := core.Action{
+		Id: core.ACTION_FEE,
+	}
+
+	if err := feeAction.SetAttributes(&feeAttr); err != nil {
+		return nil, fmt.Errorf("failed to set action attributes: %w", err)
+	}
+
+	if err := feeAction.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fee action: %w", err)
+	}
+
+	return &feeAction, nil
+}
+
+// validateTotalFees checks that the fees of all actions combined do not exceed
+// the transferred amount, which the fee actions would otherwise over-allocate.
+func validateTotalFees(actions []*core.Action) error {
+	var total uint64
+	for _, act := range actions {
+		attr, err := act.CachedAttributes()
+		if err != nil {
+			continue
+		}
+
+		fee, ok := attr.(*action.FeeAttributes)
+		if !ok {
+			continue
+		}
+
+		for _, info := range fee.FeesInfo {
+			total += uint64(info.BasisPoints)
+		}
+	}
+
+	if total > action.BPSNormalizer {
+		return newError(
+			ErrFeesExceedAmount,
+			FieldBasisPoints,
+			"total fees of %d basis points exceed the limit of %d basis points",
+			total,
+			action.BPSNormalizer,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/noble-assets/orbiter/types/controller/action"
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+)
+
+// goSourcePackage is the package clause of the generated Go source.
+const goSourcePackage = "package fixtures\n\n"
+
+// goBytesPerLine is the number of bytes per line in generated byte slice literals.
+const goBytesPerLine = 16
+
+// formatGoSource returns Go source, that reconstructs the given JSON encoded payload
+// with the constructors of the orbiter types, e.g. to embed it as a test fixture.
+func formatGoSource(payload string) (string, error) {
+	wrapper, err := DecodePayload(payload)
+	if err != nil {
+		return "", err
+	}
+
+	var s strings.Builder
+	s.WriteString(goSourcePackage)
+	s.WriteString("import (\n")
+	// NOTE: the action package is only imported if used, so that the source compiles.
+	if len(wrapper.Orbiter.PreActions) > 0 {
+		s.WriteString("\"github.com/noble-assets/orbiter/types/controller/action\"\n")
+	}
+	s.WriteString("\"github.com/noble-assets/orbiter/types/controller/forwarding\"\n")
+	s.WriteString("\"github.com/noble-assets/orbiter/types/core\"\n")
+	s.WriteString(")\n\n")
+	s.WriteString("// orbiterPayload returns the payload generated with orbgen.\n")
+	s.WriteString("func orbiterPayload() (*core.PayloadWrapper, error) {\n")
+
+	actionNames := make([]string, 0, len(wrapper.Orbiter.PreActions))
+	for i, act := range wrapper.Orbiter.PreActions {
+		attr, err := act.CachedAttributes()
+		if err != nil {
+			return "", fmt.Errorf("invalid action attributes: %w", err)
+		}
+
+		feeAttr, ok := attr.(*action.FeeAttributes)
+		if !ok {
+			return "", fmt.Errorf("%s is %w in Go source output", act.Id, ErrNotSupported)
+		}
+
+		name := fmt.Sprintf("action%d", i)
+		actionNames = append(actionNames, name)
+
+		fmt.Fprintf(&s, "%s, err := action.NewFeeAction(\n", name)
+		for _, info := range feeAttr.FeesInfo {
+			fmt.Fprintf(
+				&s,
+				"&action.FeeInfo{Recipient: %q, BasisPoints: %d},\n",
+				info.Recipient,
+				info.BasisPoints,
+			)
+		}
+		s.WriteString(")\nif err != nil {\nreturn nil, err\n}\n\n")
+	}
+
+	fwd := wrapper.Orbiter.Forwarding
+	attr, err := fwd.CachedAttributes()
+	if err != nil {
+		return "", fmt.Errorf("invalid forwarding attributes: %w", err)
+	}
+
+	switch a := attr.(type) {
+	case *forwarding.CCTPAttributes:
+		fmt.Fprintf(
+			&s,
+			"fwd, err := forwarding.NewCCTPForwarding(\n%d,\n%s,\n%s,\n%s,\n)\n",
+			a.DestinationDomain,
+			goBytes(a.MintRecipient),
+			goBytes(a.DestinationCaller),
+			goBytes(fwd.PassthroughPayload),
+		)
+	case *forwarding.InternalAttributes:
+		fmt.Fprintf(&s, "fwd, err := forwarding.NewInternalForwarding(%q)\n", a.Recipient)
+	default:
+		return "", fmt.Errorf("%s is %w in Go source output", fwd.ProtocolId, ErrNotSupported)
+	}
+	s.WriteString("if err != nil {\nreturn nil, err\n}\n\n")
+
+	fmt.Fprintf(&s, "return core.NewPayloadWrapper(%s)\n}\n", strings.Join(
+		append([]string{"fwd"}, actionNames...),
+		", ",
+	))
+
+	bz, err := format.Source([]byte(s.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format Go source: %w", err)
+	}
+
+	return string(bz), nil
+}
+
+// goBytes returns the Go expression of the given bytes. Printable text is
+// rendered as converted string literal and other bytes as byte slice literal.
+func goBytes(bz []byte) string {
+	if len(bz) == 0 {
+		return "nil"
+	}
+
+	if utf8.Valid(bz) && strings.IndexFunc(string(bz), isUnprintable) < 0 {
+		return "[]byte(" + strconv.Quote(string(bz)) + ")"
+	}
+
+	var s strings.Builder
+	s.WriteString("[]byte{\n")
+	for i, b := range bz {
+		fmt.Fprintf(&s, "0x%02x,", b)
+		if (i+1)%goBytesPerLine == 0 || i == len(bz)-1 {
+			s.WriteString("\n")
+		} else {
+			s.WriteString(" ")
+		}
+	}
+	s.WriteString("}")
+
+	return s.String()
+}
+
+// isUnprintable returns whether the rune should not be rendered in a string literal.
+func isUnprintable(r rune) bool {
+	return !strconv.IsPrint(r)
+}
@@ -0,0 +1,86 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package builder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+)
+
+// JUnitCase is a single checked payload or part of a batch run,
+// which is reported as a test case in a JUnit XML report.
+type JUnitCase struct {
+	Name string
+	// Failure is the error message, if the check failed, and empty otherwise.
+	Failure string
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes the given cases as a single test suite in JUnit XML
+// to the given file, so that batch runs can be shown in existing CI reporting.
+func WriteJUnitReport(path, suite string, cases []JUnitCase) error {
+	report := junitTestSuite{
+		Name:  suite,
+		Tests: len(cases),
+		Cases: make([]junitTestCase, 0, len(cases)),
+	}
+
+	for _, c := range cases {
+		testCase := junitTestCase{Name: c.Name, Classname: suite}
+		if c.Failure != "" {
+			report.Failures++
+			testCase.Failure = &junitFailure{Message: c.Failure, Text: c.Failure}
+		}
+
+		report.Cases = append(report.Cases, testCase)
+	}
+
+	bz, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{report}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	bz = append([]byte(xml.Header), append(bz, '\n')...)
+	if err = os.WriteFile(path, bz, 0o600); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+)
+
+// ErrNoKeyring is returned when reading keys without a configured keyring.
+var ErrNoKeyring = errors.New("no keyring configured")
+
+// keyringAppName is the service name under which the keys are stored,
+// which matches the one used by the Noble CLI.
+const keyringAppName = "noble"
+
+// KeyringEntry is a named account stored in the keyring.
+type KeyringEntry struct {
+	Name    string
+	Address string
+}
+
+// ListKeyring returns the names and Noble addresses of all keys
+// in the keyring configured in the given options.
+//
+// NOTE: only keyring backends which do not prompt for a passphrase on the terminal
+// are supported, because the keyring is read while the TUI is running.
+func ListKeyring(opts Options) ([]KeyringEntry, error) {
+	if opts.KeyringDir == "" {
+		return nil, fmt.Errorf("%w; set --keyring-dir to select addresses from it", ErrNoKeyring)
+	}
+
+	if opts.KeyringBackend != keyring.BackendOS && opts.KeyringBackend != keyring.BackendTest {
+		return nil, fmt.Errorf(
+			"keyring backend %q is not supported; expected %s or %s",
+			opts.KeyringBackend,
+			keyring.BackendOS,
+			keyring.BackendTest,
+		)
+	}
+
+	kr, err := keyring.New(
+		keyringAppName,
+		opts.KeyringBackend,
+		opts.KeyringDir,
+		os.Stdin,
+		newCodec(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keyring: %w", err)
+	}
+
+	records, err := kr.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keyring entries: %w", err)
+	}
+
+	entries := make([]KeyringEntry, 0, len(records))
+	for _, record := range records {
+		addr, err := record.GetAddress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get address of key %s: %w", record.Name, err)
+		}
+
+		entries = append(entries, KeyringEntry{Name: record.Name, Address: addr.String()})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,95 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// Options contains the optional configuration for generating payloads,
+// which is shared between the TUI and the non-interactive modes.
+type Options struct {
+	// ENSRPC is the Ethereum RPC endpoint used to resolve ENS names.
+	// ENS resolution is disabled if empty.
+	ENSRPC string
+	// MaxPassthroughSize is the maximum size of passthrough payloads in bytes,
+	// as configured on-chain through the orbiter adapter parameters.
+	// It replaces DefaultMaxPassthroughSize, but is capped by the known limit of the destination.
+	// Only the limit of the destination or the default is checked if zero.
+	MaxPassthroughSize uint32
+	// Strict rejects addresses, that have to be padded to 32 bytes,
+	// except for 20 byte addresses on EVM domains, as well as percentages,
+	// that would have to be rounded to a whole number of basis points.
+	Strict bool
+	// MintRecipientPadding is the alignment of mint recipients shorter than 32 bytes.
+	// PaddingAuto is used if empty.
+	MintRecipientPadding Padding
+	// DestinationCallerPadding is the alignment of destination callers shorter than 32 bytes.
+	// PaddingAuto is used if empty.
+	DestinationCallerPadding Padding
+	// AllowZeroRecipient accepts CCTP mint recipients, that are the zero address.
+	// These are rejected by default, because the minted funds would be unrecoverable.
+	AllowZeroRecipient bool
+	// Experimental enables listing actions and protocols in the TUI,
+	// which are not supported by the generator yet.
+	Experimental bool
+	// Expert shows the forwarding and all fee actions on a single screen in the TUI,
+	// instead of guiding through the individual steps.
+	Expert bool
+	// ForwardingFirst configures the forwarding before selecting the actions in the TUI,
+	// instead of selecting the actions first.
+	ForwardingFirst bool
+	// KeyringBackend is the backend of the keyring to select addresses from.
+	KeyringBackend string
+	// KeyringDir is the directory containing the keyring.
+	// Selecting addresses from the keyring is disabled if empty.
+	KeyringDir string
+	// IdleTimeout is the duration without key presses, after which the TUI quits
+	// without building a payload. The TUI does not time out if zero.
+	IdleTimeout time.Duration
+	// SelectFormat enables a final step in the TUI to select the output format.
+	SelectFormat bool
+	// OutputFormat is the output format, that is preselected in the TUI.
+	OutputFormat Format
+	// DefaultDestinationCallers contains the destination callers by CCTP domain,
+	// that are prefilled in the TUI when selecting the domain.
+	DefaultDestinationCallers map[uint32]string
+	// KnownCallers contains the named destination callers by CCTP domain,
+	// which can be entered by name instead of their address.
+	KnownCallers map[uint32][]KnownCaller
+	// PassthroughABI is the ABI of the contract targeted by the passthrough payload.
+	// If set, CCTP passthrough payloads have to be calldata of one of its functions.
+	PassthroughABI *abi.ABI
+	// Imported is a previously generated payload, whose actions and forwarding are preloaded
+	// in the TUI, so that further actions can be appended to it. The forwarding is kept as is.
+	Imported *core.PayloadWrapper
+	// Review starts the TUI on the confirmation screen of the imported payload,
+	// instead of the action selection.
+	Review bool
+	// Profile contains the default values of the selected profile, that are prefilled in the TUI.
+	Profile Profile
+	// Language is the language of the TUI.
+	// The language of the locale environment variables is used if empty, or English if unavailable.
+	Language string
+	// Theme is the name of the theme, that is used to render the TUI.
+	// The default theme is used if empty, or the no-color theme if NO_COLOR is set.
+	Theme string
+}
@@ -0,0 +1,362 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/noble-assets/orbiter/types/controller/action"
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+)
+
+// Format is the encoding in which a generated payload is output.
+type Format string
+
+const (
+	// FormatRaw outputs the payload as is, which is the memo to be used
+	// in the ICS-20 transfer.
+	FormatRaw Format = "raw"
+	// FormatJSON outputs the decoded actions and forwarding as a JSON document,
+	// along with the raw payload.
+	FormatJSON Format = "json"
+	// FormatBase64 outputs the base64 encoding of the raw payload.
+	FormatBase64 Format = "base64"
+	// FormatHex outputs the hex encoding of the raw payload.
+	FormatHex Format = "hex"
+	// FormatProtobuf outputs the hex encoded protobuf binary of the payload.
+	FormatProtobuf Format = "protobuf"
+	// FormatDataURI outputs the base64 encoded raw payload as a data URI,
+	// to be pasted into browser-based tools.
+	FormatDataURI Format = "datauri"
+	// FormatGo outputs Go source, that reconstructs the payload with the orbiter types.
+	FormatGo Format = "go"
+)
+
+// dataURIPrefix is prepended to the base64 encoded payload in the data URI format.
+const dataURIPrefix = "data:application/octet-stream;base64,"
+
+// Formats contains all supported output formats.
+var Formats = []Format{
+	FormatRaw,
+	FormatJSON,
+	FormatBase64,
+	FormatHex,
+	FormatProtobuf,
+	FormatDataURI,
+	FormatGo,
+}
+
+// Description returns a short human-readable description of the output format.
+func (f Format) Description() string {
+	switch f {
+	case FormatRaw:
+		return "The payload as is, to be used as ICS-20 memo"
+	case FormatJSON:
+		return "The decoded actions and forwarding as JSON document"
+	case FormatBase64:
+		return "The base64 encoding of the payload"
+	case FormatHex:
+		return "The hex encoding of the payload"
+	case FormatProtobuf:
+		return "The hex encoded protobuf binary of the payload"
+	case FormatDataURI:
+		return "The base64 encoding of the payload as data URI"
+	case FormatGo:
+		return "Go source reconstructing the payload with the orbiter types"
+	default:
+		return "unknown output format"
+	}
+}
+
+// ParseFormat returns the output format with the given name.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(Formats, format) {
+		return "", fmt.Errorf("unknown output format %q; expected one of %v", name, Formats)
+	}
+
+	return format, nil
+}
+
+// ParseFormats returns the output formats with the given comma-separated names,
+// e.g. "raw,base64,hex". Each format can only be given once.
+func ParseFormats(names string) ([]Format, error) {
+	parts := strings.Split(names, ",")
+	formats := make([]Format, 0, len(parts))
+	for _, name := range parts {
+		format, err := ParseFormat(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(formats, format) {
+			return nil, fmt.Errorf("output format %q is given more than once", format)
+		}
+
+		formats = append(formats, format)
+	}
+
+	return formats, nil
+}
+
+// OutputOptions configure how a generated payload is output.
+type OutputOptions struct {
+	Format Format
+	// Compact disables the indentation of JSON output,
+	// so that every payload is output on a single line.
+	Compact bool
+	// Metadata contains arbitrary key value pairs, that are included
+	// in the JSON output. Other formats do not contain the metadata.
+	Metadata map[string]string
+	// ActionNotes contains optional free-text notes of the actions by index,
+	// which are included in the JSON output. The payload itself is not affected.
+	ActionNotes []string
+	// KnownCallers contains the named destination callers by CCTP domain,
+	// whose names are included in the JSON output.
+	KnownCallers map[uint32][]KnownCaller
+}
+
+// FormatPayload returns the given JSON encoded payload in the configured output format.
+func FormatPayload(payload string, opts OutputOptions) (string, error) {
+	switch opts.Format {
+	case FormatRaw, "":
+		return payload, nil
+	case FormatJSON:
+		return formatJSON(payload, opts)
+	case FormatBase64:
+		return base64.StdEncoding.EncodeToString([]byte(payload)), nil
+	case FormatHex:
+		return hexutil.Encode([]byte(payload)), nil
+	case FormatProtobuf:
+		wrapper, err := DecodePayload(payload)
+		if err != nil {
+			return "", err
+		}
+
+		bz, err := wrapper.Marshal()
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal payload to protobuf: %w", err)
+		}
+
+		return hexutil.Encode(bz), nil
+	case FormatDataURI:
+		return dataURIPrefix + base64.StdEncoding.EncodeToString([]byte(payload)), nil
+	case FormatGo:
+		return formatGoSource(payload)
+	default:
+		return "", fmt.Errorf("unknown output format %q", opts.Format)
+	}
+}
+
+// FormatPayloadLabeled returns the given JSON encoded payload in each of the given formats,
+// in sequence. Each encoding is labeled with the name of its format.
+func FormatPayloadLabeled(payload string, formats []Format, opts OutputOptions) (string, error) {
+	encodings := make([]string, 0, len(formats))
+	for _, format := range formats {
+		opts.Format = format
+
+		encoded, err := FormatPayload(payload, opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to format payload as %s: %w", format, err)
+		}
+
+		encodings = append(encodings, fmt.Sprintf("%s:\n%s", format, encoded))
+	}
+
+	return strings.Join(encodings, "\n\n"), nil
+}
+
+// jsonDocument is the structure of the JSON output format. It presents the decoded actions
+// and forwarding separately, along with the raw payload to be used as ICS-20 memo.
+type jsonDocument struct {
+	Actions    []jsonAction   `json:"actions"`
+	Forwarding jsonForwarding `json:"forwarding"`
+	Payload    string         `json:"payload"`
+
+	// PayloadLength and PayloadSHA256 are the byte length and the hex encoded SHA-256
+	// checksum of the raw payload, so that recipients can verify its integrity.
+	PayloadLength int    `json:"payload_length"`
+	PayloadSHA256 string `json:"payload_sha256"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// jsonAction is a decoded action in the JSON output format.
+type jsonAction struct {
+	ID   string   `json:"id"`
+	Note string   `json:"note,omitempty"`
+	Fee  *jsonFee `json:"fee,omitempty"`
+}
+
+// jsonFee contains the decoded attributes of a fee action.
+type jsonFee struct {
+	Fees []FeeSpec `json:"fees"`
+}
+
+// jsonForwarding is the decoded forwarding in the JSON output format.
+// Binary values are hex encoded.
+type jsonForwarding struct {
+	Protocol           string        `json:"protocol"`
+	CCTP               *jsonCCTP     `json:"cctp,omitempty"`
+	Internal           *InternalSpec `json:"internal,omitempty"`
+	PassthroughPayload string        `json:"passthrough_payload,omitempty"`
+}
+
+// jsonCCTP contains the decoded attributes of a CCTP forwarding.
+type jsonCCTP struct {
+	DestinationDomain     uint32 `json:"destination_domain"`
+	DestinationDomainName string `json:"destination_domain_name"`
+	MintRecipient         string `json:"mint_recipient"`
+	DestinationCaller     string `json:"destination_caller,omitempty"`
+	DestinationCallerName string `json:"destination_caller_name,omitempty"`
+}
+
+// formatJSON returns the decoded payload contents as a JSON document, that includes the metadata.
+func formatJSON(payload string, opts OutputOptions) (string, error) {
+	doc, err := newJSONDocument(payload, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to format payload as JSON: %w", err)
+	}
+
+	doc.Metadata = opts.Metadata
+	for i, note := range opts.ActionNotes {
+		if i < len(doc.Actions) {
+			doc.Actions[i].Note = note
+		}
+	}
+
+	var bz []byte
+	if opts.Compact {
+		bz, err = json.Marshal(doc)
+	} else {
+		bz, err = json.MarshalIndent(doc, "", "  ")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to format payload as JSON: %w", err)
+	}
+
+	return string(bz), nil
+}
+
+// newJSONDocument decodes the given payload into the structure of the JSON output format.
+func newJSONDocument(payload string, opts OutputOptions) (jsonDocument, error) {
+	wrapper, err := DecodePayload(payload)
+	if err != nil {
+		return jsonDocument{}, err
+	}
+
+	checksum := sha256.Sum256([]byte(payload))
+	doc := jsonDocument{
+		Actions:       make([]jsonAction, 0, len(wrapper.Orbiter.PreActions)),
+		Payload:       payload,
+		PayloadLength: len(payload),
+		PayloadSHA256: hex.EncodeToString(checksum[:]),
+	}
+
+	for _, act := range wrapper.Orbiter.PreActions {
+		attr, err := act.CachedAttributes()
+		if err != nil {
+			return jsonDocument{}, fmt.Errorf("invalid action attributes: %w", err)
+		}
+
+		feeAttr, ok := attr.(*action.FeeAttributes)
+		if !ok {
+			return jsonDocument{}, fmt.Errorf("%s is %w in JSON output", act.Id, ErrNotSupported)
+		}
+
+		fee := &jsonFee{Fees: make([]FeeSpec, 0, len(feeAttr.FeesInfo))}
+		for _, info := range feeAttr.FeesInfo {
+			fee.Fees = append(fee.Fees, FeeSpec{
+				Recipient:   info.Recipient,
+				BasisPoints: info.BasisPoints,
+			})
+		}
+
+		doc.Actions = append(doc.Actions, jsonAction{ID: act.Id.String(), Fee: fee})
+	}
+
+	fwd := wrapper.Orbiter.Forwarding
+	attr, err := fwd.CachedAttributes()
+	if err != nil {
+		return jsonDocument{}, fmt.Errorf("invalid forwarding attributes: %w", err)
+	}
+
+	doc.Forwarding.Protocol = fwd.ProtocolId.String()
+	if len(fwd.PassthroughPayload) > 0 {
+		doc.Forwarding.PassthroughPayload = hexutil.Encode(fwd.PassthroughPayload)
+	}
+
+	switch a := attr.(type) {
+	case *forwarding.CCTPAttributes:
+		doc.Forwarding.CCTP = &jsonCCTP{
+			DestinationDomain:     a.DestinationDomain,
+			DestinationDomainName: CCTPDomainName(a.DestinationDomain),
+			MintRecipient:         hexutil.Encode(a.MintRecipient),
+		}
+		if len(a.DestinationCaller) > 0 {
+			doc.Forwarding.CCTP.DestinationCaller = hexutil.Encode(a.DestinationCaller)
+			doc.Forwarding.CCTP.DestinationCallerName = KnownCallerName(
+				Options{KnownCallers: opts.KnownCallers},
+				a.DestinationDomain,
+				a.DestinationCaller,
+			)
+		}
+	case *forwarding.InternalAttributes:
+		doc.Forwarding.Internal = &InternalSpec{Recipient: a.Recipient}
+	default:
+		return jsonDocument{}, fmt.Errorf(
+			"%s is %w in JSON output",
+			fwd.ProtocolId,
+			ErrNotSupported,
+		)
+	}
+
+	return doc, nil
+}
+
+// ValidateMetadataKey checks that the given key can be used in the output metadata.
+func ValidateMetadataKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return errors.New("metadata keys cannot be empty")
+	}
+
+	return nil
+}
+
+// ParseMetadata parses a metadata entry in the key=value form.
+func ParseMetadata(entry string) (key, value string, err error) {
+	key, value, found := strings.Cut(entry, "=")
+	if !found {
+		return "", "", fmt.Errorf("invalid metadata %q; expected key=value", entry)
+	}
+
+	key = strings.TrimSpace(key)
+	if err = ValidateMetadataKey(key); err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package builder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Padding is the alignment of CCTP addresses shorter than 32 bytes in their 32 byte field.
+type Padding string
+
+const (
+	// PaddingAuto left-pads addresses like PaddingLeft,
+	// but rejects ambiguous lengths in strict mode.
+	PaddingAuto Padding = "auto"
+	// PaddingLeft prepends zero bytes, which aligns big-endian addresses like EVM addresses.
+	PaddingLeft Padding = "left"
+	// PaddingRight appends zero bytes, for representations that expect left-aligned bytes.
+	PaddingRight Padding = "right"
+	// PaddingNone does not pad, so that addresses have to be exactly 32 bytes.
+	PaddingNone Padding = "none"
+)
+
+// Paddings contains all supported padding modes, in the order they are cycled through.
+var Paddings = []Padding{PaddingAuto, PaddingLeft, PaddingRight, PaddingNone}
+
+// ParsePadding returns the padding mode with the given name.
+// An empty name selects PaddingAuto.
+func ParsePadding(name string) (Padding, error) {
+	padding := Padding(strings.ToLower(strings.TrimSpace(name)))
+	if padding == "" {
+		return PaddingAuto, nil
+	}
+
+	if !slices.Contains(Paddings, padding) {
+		return "", fmt.Errorf("unknown padding %q; expected one of %v", name, Paddings)
+	}
+
+	return padding, nil
+}
+
+// Next returns the padding mode following this one in Paddings.
+func (p Padding) Next() Padding {
+	index := slices.Index(Paddings, p)
+
+	return Paddings[(index+1)%len(Paddings)]
+}
+
+// AmbiguousAddressLength returns the length of the given address in bytes, if it is valid
+// but shorter than 32 bytes, so that its alignment depends on the padding mode.
+// Empty inputs are not ambiguous, as they are not padded at all.
+// 20 byte addresses on EVM domains are not ambiguous, as they are always left-padded.
+func AmbiguousAddressLength(domain uint32, input string) (int, bool) {
+	decoded, err := decodeAddressBytes(input)
+	if err != nil || len(decoded) == 0 || len(decoded) >= 32 {
+		return 0, false
+	}
+
+	if d, found := LookupCCTPDomain(domain); found && d.EVM && len(decoded) == 20 {
+		return 0, false
+	}
+
+	return len(decoded), true
+}
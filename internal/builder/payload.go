@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/noble-assets/orbiter"
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types"
+	"github.com/noble-assets/orbiter/types/core"
+
+	errorsmod "cosmossdk.io/errors"
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// newCodec returns a codec with all Orbiter interfaces registered,
+// which is required to encode and decode payloads.
+func newCodec() codec.Codec {
+	encCfg := testutil.MakeTestEncodingConfig("noble")
+	orbiter.RegisterInterfaces(encCfg.InterfaceRegistry)
+
+	return encCfg.Codec
+}
+
+// BuildPayload creates the payload wrapper from the given forwarding and actions
+// and returns its JSON encoding. A forwarding is required, because Orbiter
+// does not support payloads that only contain actions. The actions may be empty,
+// which results in a forwarding-only payload with an empty list of pre actions.
+// The fees of all actions combined must not exceed the transferred amount.
+func BuildPayload(forwarding *core.Forwarding, actions []*core.Action) (string, error) {
+	if forwarding == nil {
+		return "", ErrMissingForwarding
+	}
+
+	if err := validateTotalFees(actions); err != nil {
+		return "", err
+	}
+
+	payload, err := core.NewPayloadWrapper(forwarding, actions...)
+	if err != nil {
+		return "", errorsmod.Wrap(err, "failed to create payload wrapper")
+	}
+
+	payloadBz, err := types.MarshalJSON(newCodec(), payload)
+	if err != nil {
+		return "", errorsmod.Wrap(err, "failed to marshal payload")
+	}
+
+	return string(payloadBz), nil
+}
+
+// DecodePayload decodes the given JSON encoded Orbiter payload
+// and returns the validated payload wrapper.
+func DecodePayload(payload string) (*core.PayloadWrapper, error) {
+	var wrapper core.PayloadWrapper
+	err := types.UnmarshalJSON(newCodec(), []byte(strings.TrimSpace(payload)), &wrapper)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "failed to unmarshal payload")
+	}
+
+	if err = wrapper.Validate(); err != nil {
+		return nil, errorsmod.Wrap(err, "invalid payload")
+	}
+
+	return &wrapper, nil
+}
+
+// DecodePayloadFile reads and decodes the Orbiter payload stored in the given file.
+func DecodePayloadFile(path string) (*core.PayloadWrapper, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "failed to read payload file")
+	}
+
+	return DecodePayload(string(bz))
+}
+
+// WritePayloadFile atomically replaces the given file with the payload, so that
+// the file is never left partially written. The permissions of an existing file are kept.
+func WritePayloadFile(path, payload string) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return errorsmod.Wrap(err, "failed to create temporary payload file")
+	}
+	// NOTE: removing the temporary file fails once it was renamed, which is ignored.
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(payload + "\n"); err != nil {
+		tmp.Close()
+
+		return errorsmod.Wrap(err, "failed to write payload file")
+	}
+
+	if err = tmp.Chmod(mode); err != nil {
+		tmp.Close()
+
+		return errorsmod.Wrap(err, "failed to set payload file permissions")
+	}
+
+	if err = tmp.Close(); err != nil {
+		return errorsmod.Wrap(err, "failed to write payload file")
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return errorsmod.Wrap(err, "failed to replace payload file")
+	}
+
+	return nil
+}
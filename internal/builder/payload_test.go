@@ -15,30 +15,23 @@
 // specific language governing permissions and limitations
 // under the License.
 
-package internal
+package builder_test
 
 import (
-	"github.com/noble-assets/orbiter"
+	"testing"
+
 	"github.com/noble-assets/orbiter/testutil"
-	"github.com/noble-assets/orbiter/types"
 	"github.com/noble-assets/orbiter/types/core"
+	"github.com/stretchr/testify/require"
 
-	errorsmod "cosmossdk.io/errors"
+	"github.com/noble-assets/orbgen/internal/builder"
 )
 
-func buildFinalPayload(forwarding *core.Forwarding, actions []*core.Action) (string, error) {
-	payload, err := core.NewPayloadWrapper(forwarding, actions...)
-	if err != nil {
-		return "", errorsmod.Wrap(err, "failed to create payload wrapper")
-	}
-
-	encCfg := testutil.MakeTestEncodingConfig("noble")
-	orbiter.RegisterInterfaces(encCfg.InterfaceRegistry)
-
-	payloadBz, err := types.MarshalJSON(encCfg.Codec, payload)
-	if err != nil {
-		return "", errorsmod.Wrap(err, "failed to marshal payload")
-	}
+func TestBuildPayloadWithoutForwarding(t *testing.T) {
+	fee, err := builder.NewFeeAction(testutil.NewNobleAddress(), 100)
+	require.NoError(t, err)
 
-	return string(payloadBz), nil
+	payload, err := builder.BuildPayload(nil, []*core.Action{fee})
+	require.ErrorIs(t, err, builder.ErrMissingForwarding)
+	require.Empty(t, payload)
 }
@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// profileNameRegex matches the allowed names of profiles,
+// which are used as file names within the config directory.
+var profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Profile contains the default values for an environment (e.g. mainnet or testnet),
+// that are loaded by name and prefilled in the TUI.
+type Profile struct {
+	// Bech32Prefix is the account address prefix, that is used to validate Noble addresses.
+	// The default Noble prefix is used if empty.
+	Bech32Prefix string `json:"bech32_prefix,omitempty"`
+	// DestinationDomain is the CCTP destination domain, if any.
+	DestinationDomain *uint32 `json:"destination_domain,omitempty"`
+	// MintRecipient is the CCTP mint recipient.
+	MintRecipient string `json:"mint_recipient,omitempty"`
+	// Recipient is the recipient of internal forwardings.
+	Recipient string `json:"recipient,omitempty"`
+	// FeeRecipient is the recipient of fee actions.
+	FeeRecipient string `json:"fee_recipient,omitempty"`
+	// DefaultDestinationCallers contains the destination callers by CCTP domain,
+	// which take precedence over the ones persisted in the config.
+	DefaultDestinationCallers map[uint32]string `json:"default_destination_callers,omitempty"`
+	// KnownCallers contains the named destination callers by CCTP domain,
+	// which replace the ones with the same name in the config.
+	KnownCallers map[uint32][]KnownCaller `json:"known_callers,omitempty"`
+}
+
+// ProfilePath returns the path of the profile with the given name.
+func ProfilePath(name string) (string, error) {
+	if !profileNameRegex.MatchString(name) {
+		return "", fmt.Errorf(
+			"invalid profile name %q; only letters, digits, '-' and '_' are allowed",
+			name,
+		)
+	}
+
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(path), "profiles", name+".json"), nil
+}
+
+// LoadProfile reads the profile with the given name.
+// Unknown fields are rejected to surface typos early.
+func LoadProfile(name string) (Profile, error) {
+	path, err := ProfilePath(name)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Profile{}, fmt.Errorf("profile %q not found at %s", name, path)
+	} else if err != nil {
+		return Profile{}, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	var profile Profile
+
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&profile); err != nil {
+		return Profile{}, fmt.Errorf("failed to decode profile %s: %w", path, err)
+	}
+
+	return profile, nil
+}
+
+// ApplyBech32Prefix sets the account address prefix of the profile in the SDK config,
+// if the profile has one.
+func (p Profile) ApplyBech32Prefix() {
+	if p.Bech32Prefix == "" {
+		return
+	}
+
+	sdk.GetConfig().SetBech32PrefixForAccount(p.Bech32Prefix, p.Bech32Prefix+"pub")
+}
@@ -0,0 +1,138 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// ErrInvalidQuery is returned for shared query strings, that cannot be parsed into a spec.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// queryKeys contains the supported parameters of a shared query string.
+var queryKeys = []string{"protocol", "domain", "recipient", "caller", "passthrough", "fee"}
+
+// SpecFromQuery creates the spec for a payload from a compact, URL-like query string,
+// which can be shared to describe a payload in a single line, e.g.:
+//
+//	orbgen?protocol=cctp&domain=6&recipient=0x...&fee=noble1...:100
+//
+// The fee parameter can be repeated and contains the recipient and basis points,
+// separated by a colon (see ParseFee). Unknown parameters are rejected to surface typos early.
+//
+// A '+' is kept as is rather than decoded as a space, so that base64 encoded addresses
+// can be shared without percent-encoding. Spaces have to be encoded as %20 instead.
+// Passthrough payloads cannot be read from files, because a shared query string
+// must not copy local files into the payload.
+func SpecFromQuery(opts Options, input string) (Spec, error) {
+	rawQuery := input
+	if _, after, found := strings.Cut(input, "?"); found {
+		rawQuery = after
+	}
+
+	params, err := url.ParseQuery(strings.ReplaceAll(rawQuery, "+", "%2B"))
+	if err != nil {
+		return Spec{}, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+
+	var unknown []string
+	for key, values := range params {
+		if !slices.Contains(queryKeys, key) {
+			unknown = append(unknown, key)
+		}
+		if key != "fee" && len(values) > 1 {
+			return Spec{}, fmt.Errorf(
+				"%w: parameter %q is given more than once",
+				ErrInvalidQuery,
+				key,
+			)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+
+		return Spec{}, fmt.Errorf(
+			"%w: unknown parameters %s; supported are %s",
+			ErrInvalidQuery,
+			strings.Join(unknown, ", "),
+			strings.Join(queryKeys, ", "),
+		)
+	}
+
+	var spec Spec
+	for _, entry := range params["fee"] {
+		fee, err := ParseFee(opts, entry)
+		if err != nil {
+			return Spec{}, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+		}
+
+		spec.Actions = append(spec.Actions, ActionSpec{ID: core.ACTION_FEE.String(), Fee: &fee})
+	}
+
+	switch protocol := params.Get("protocol"); strings.ToLower(protocol) {
+	case "cctp":
+		if strings.HasPrefix(params.Get("passthrough"), "@") {
+			return Spec{}, fmt.Errorf(
+				"%w: passthrough payloads cannot be read from files in shared queries",
+				ErrInvalidQuery,
+			)
+		}
+
+		domain, err := ParseDomain(params.Get("domain"))
+		if err != nil {
+			return Spec{}, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+		}
+
+		spec.Forwarding = ForwardingSpec{
+			Protocol: core.PROTOCOL_CCTP.String(),
+			CCTP: &CCTPSpec{
+				DestinationDomain:  domain,
+				MintRecipient:      params.Get("recipient"),
+				DestinationCaller:  params.Get("caller"),
+				PassthroughPayload: params.Get("passthrough"),
+			},
+		}
+	case "internal":
+		for _, key := range []string{"domain", "caller", "passthrough"} {
+			if params.Has(key) {
+				return Spec{}, fmt.Errorf(
+					"%w: parameter %q is not supported for internal forwarding",
+					ErrInvalidQuery,
+					key,
+				)
+			}
+		}
+
+		spec.Forwarding = ForwardingSpec{
+			Protocol: core.PROTOCOL_INTERNAL.String(),
+			Internal: &InternalSpec{Recipient: params.Get("recipient")},
+		}
+	case "":
+		return Spec{}, fmt.Errorf("%w: expected a protocol", ErrInvalidQuery)
+	default:
+		return Spec{}, fmt.Errorf("%w: unknown protocol %q", ErrInvalidQuery, protocol)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+)
+
+// maxRecentPayloads is the maximum number of recently generated payloads, that are kept.
+const maxRecentPayloads = 10
+
+// RecentPayload is a recently generated payload.
+type RecentPayload struct {
+	CreatedAt time.Time `json:"created_at"`
+	// Label summarizes the payload contents, e.g. the forwarding destination.
+	Label   string `json:"label"`
+	Payload string `json:"payload"`
+}
+
+// recentPath returns the path of the file containing the recently generated payloads,
+// which is stored next to the config file.
+func recentPath() (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(path), "recent.json"), nil
+}
+
+// LoadRecentPayloads returns the recently generated payloads, starting with the newest.
+// An empty list is returned if no payloads were recorded yet.
+func LoadRecentPayloads() ([]RecentPayload, error) {
+	path, err := recentPath()
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read recent payloads: %w", err)
+	}
+
+	var recent []RecentPayload
+	if err = json.Unmarshal(bz, &recent); err != nil {
+		return nil, fmt.Errorf("failed to decode recent payloads %s: %w", path, err)
+	}
+
+	return recent, nil
+}
+
+// AddRecentPayload records the given payload as the newest of the recently generated payloads,
+// dropping the oldest ones beyond the maximum number of recent payloads.
+func AddRecentPayload(payload string) error {
+	recent, err := LoadRecentPayloads()
+	if err != nil {
+		return err
+	}
+
+	recent = slices.Insert(recent, 0, RecentPayload{
+		CreatedAt: time.Now(),
+		Label:     recentLabel(payload),
+		Payload:   payload,
+	})
+	recent = recent[:min(len(recent), maxRecentPayloads)]
+
+	path, err := recentPath()
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.MarshalIndent(recent, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recent payloads: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err = os.WriteFile(path, bz, 0o600); err != nil {
+		return fmt.Errorf("failed to write recent payloads: %w", err)
+	}
+
+	return nil
+}
+
+// recentLabel summarizes the forwarding and the number of actions of the given payload.
+func recentLabel(payload string) string {
+	wrapper, err := DecodePayload(payload)
+	if err != nil {
+		return "invalid payload"
+	}
+
+	fwd := wrapper.Orbiter.Forwarding
+	label := fwd.ProtocolId.String()
+
+	attr, err := fwd.CachedAttributes()
+	if err == nil {
+		switch a := attr.(type) {
+		case *forwarding.CCTPAttributes:
+			label += " to " + CCTPDomainName(a.DestinationDomain)
+		case *forwarding.InternalAttributes:
+			label += " to " + a.Recipient
+		}
+	}
+
+	return fmt.Sprintf("%s with %d action(s)", label, len(wrapper.Orbiter.PreActions))
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// SpecSchema returns the JSON schema describing the spec file format.
+//
+// NOTE: The schema is derived from the Spec type via reflection, so that both stay in sync.
+func SpecSchema() ([]byte, error) {
+	schema := schemaForType(reflect.TypeOf(Spec{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Orbiter payload spec"
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaForType returns the JSON schema for the given Go type,
+// using the json, desc and enum struct tags of its fields.
+func schemaForType(t reflect.Type) map[string]any {
+	switch t.Kind() { //nolint:exhaustive // only the kinds used in the spec are supported
+	case reflect.Pointer:
+		return schemaForType(t.Elem())
+	case reflect.Struct:
+		properties := make(map[string]any)
+		required := make([]string, 0, t.NumField())
+
+		for i := range t.NumField() {
+			field := t.Field(i)
+
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			property := schemaForType(field.Type)
+			if desc := field.Tag.Get("desc"); desc != "" {
+				property["description"] = desc
+			}
+			if enum := field.Tag.Get("enum"); enum != "" {
+				property["enum"] = enumValues(enum)
+			}
+
+			properties[name] = property
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	case reflect.Slice:
+		return map[string]any{
+			"type":  "array",
+			"items": schemaForType(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": schemaForType(t.Elem()),
+		}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Uint32:
+		return map[string]any{"type": "integer", "minimum": 0, "maximum": math.MaxUint32}
+	default:
+		panic(fmt.Sprintf("unsupported type in spec schema: %s", t))
+	}
+}
+
+// enumValues returns the allowed values for the given enum tag, derived from
+// the identifiers defined in the orbiter core types, or the supported padding modes.
+func enumValues(enum string) []string {
+	if enum == "padding" {
+		values := make([]string, 0, len(Paddings))
+		for _, padding := range Paddings {
+			values = append(values, string(padding))
+		}
+
+		return values
+	}
+
+	var names map[int32]string
+	switch enum {
+	case "action":
+		names = core.ActionID_name
+	case "protocol":
+		names = core.ProtocolID_name
+	default:
+		panic("unknown enum in spec schema: " + enum)
+	}
+
+	ids := make([]int32, 0, len(names))
+	for id := range names {
+		// NOTE: the zero value is the unsupported identifier.
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, names[id])
+	}
+
+	return values
+}
@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// SimulationResult contains the outcome of a successful transaction simulation.
+type SimulationResult struct {
+	// GasUsed is the amount of gas consumed by the simulated transaction.
+	GasUsed uint64
+}
+
+// SimulateTransferTx simulates the transfer transaction carrying the given payload
+// against the gRPC endpoint of a source chain node, without broadcasting it.
+// The address is dialed without TLS, unless it is prefixed with https://.
+func SimulateTransferTx(
+	ctx context.Context,
+	address string,
+	payload string,
+	cfg TransferTxConfig,
+) (SimulationResult, error) {
+	encCfg, txBuilder, err := newTransferTx(payload, cfg)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	authtypes.RegisterInterfaces(encCfg.InterfaceRegistry)
+
+	creds := insecure.NewCredentials()
+	if after, found := strings.CutPrefix(address, "https://"); found {
+		address = after
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return SimulationResult{}, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	accountRes, err := authtypes.NewQueryClient(conn).Account(
+		ctx,
+		&authtypes.QueryAccountRequest{Address: cfg.Sender},
+	)
+	if err != nil {
+		return SimulationResult{}, fmt.Errorf("failed to query sender account: %w", err)
+	}
+
+	var account sdk.AccountI
+	if err = encCfg.InterfaceRegistry.UnpackAny(accountRes.Account, &account); err != nil {
+		return SimulationResult{}, fmt.Errorf("failed to decode sender account: %w", err)
+	}
+
+	// NOTE: Signatures are not verified during simulation,
+	// but the signer info is required to check the sequence.
+	if err = txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   account.GetPubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT},
+		Sequence: account.GetSequence(),
+	}); err != nil {
+		return SimulationResult{}, fmt.Errorf("failed to set signer info: %w", err)
+	}
+
+	txBz, err := encCfg.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return SimulationResult{}, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	simRes, err := txtypes.NewServiceClient(conn).Simulate(
+		ctx,
+		&txtypes.SimulateRequest{TxBytes: txBz},
+	)
+	if err != nil {
+		return SimulationResult{}, fmt.Errorf("simulation failed: %w", err)
+	}
+
+	return SimulationResult{GasUsed: simRes.GetGasInfo().GetGasUsed()}, nil
+}
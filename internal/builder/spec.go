@@ -0,0 +1,208 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// Spec describes the contents of an Orbiter payload in a file-based format,
+// which enables generating payloads without the interactive TUI.
+type Spec struct {
+	Actions    []ActionSpec      `json:"actions,omitempty"  desc:"Optional actions, that are run sequentially before forwarding"`
+	Forwarding ForwardingSpec    `json:"forwarding"         desc:"Forwarding of the funds to the destination"`
+	Metadata   map[string]string `json:"metadata,omitempty" desc:"Optional metadata, that is included in the JSON output format"`
+}
+
+// ActionSpec describes a single action of the payload.
+// The attributes matching the action ID have to be set.
+type ActionSpec struct {
+	ID   string   `json:"id"             desc:"Action identifier"                                    enum:"action"`
+	Fee  *FeeSpec `json:"fee,omitempty"  desc:"Attributes of the fee action"`
+	Note string   `json:"note,omitempty" desc:"Optional note, that is only included in the JSON output"`
+}
+
+// FeeSpec contains the attributes of a fee action.
+type FeeSpec struct {
+	Recipient   string `json:"recipient"    desc:"Bech32 address of the fee recipient"`
+	BasisPoints uint32 `json:"basis_points" desc:"Fee in basis points of the transferred amount (e.g. 100 for 1%)"`
+}
+
+// ForwardingSpec describes the forwarding of the payload.
+// The attributes matching the protocol have to be set.
+//
+// NOTE: An Orbiter payload contains exactly one forwarding. Splitting a transfer
+// across multiple destinations requires generating one payload per destination.
+type ForwardingSpec struct {
+	Protocol string        `json:"protocol"           desc:"Protocol identifier"                     enum:"protocol"`
+	CCTP     *CCTPSpec     `json:"cctp,omitempty"     desc:"Attributes of the CCTP forwarding"`
+	Internal *InternalSpec `json:"internal,omitempty" desc:"Attributes of the internal forwarding"`
+}
+
+// CCTPSpec contains the attributes of a CCTP forwarding.
+type CCTPSpec struct {
+	DestinationDomain        uint32 `json:"destination_domain"                   desc:"CCTP destination domain (e.g. 0 for Ethereum)"`
+	MintRecipient            string `json:"mint_recipient"                       desc:"Hex (0x-prefixed), bech32 or base64 encoded mint recipient, or 'r' for random"`
+	DestinationCaller        string `json:"destination_caller,omitempty"         desc:"Hex (0x-prefixed), bech32 or base64 encoded destination caller, 'r' for random, 'self' for the mint recipient, or the name of a known caller of the domain"`
+	PassthroughPayload       string `json:"passthrough_payload,omitempty"        desc:"Additional data to pass through, or @path to read it from a file"`
+	MintRecipientPadding     string `json:"mint_recipient_padding,omitempty"     desc:"Alignment of mint recipients shorter than 32 bytes (auto left-pads)" enum:"padding"`
+	DestinationCallerPadding string `json:"destination_caller_padding,omitempty" desc:"Alignment of destination callers shorter than 32 bytes (auto left-pads)" enum:"padding"`
+}
+
+// InternalSpec contains the attributes of an internal forwarding.
+type InternalSpec struct {
+	Recipient string `json:"recipient" desc:"Bech32 Noble address to receive the tokens"`
+}
+
+// LoadSpec reads and decodes the spec file at the given path.
+// Unknown fields are rejected to surface typos early.
+func LoadSpec(path string) (Spec, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return Spec{}, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	// NOTE: A list of forwardings is rejected explicitly instead of
+	// failing with a generic decoding error.
+	var raw struct {
+		Forwarding json.RawMessage `json:"forwarding"`
+	}
+	if err = json.Unmarshal(bz, &raw); err == nil &&
+		bytes.HasPrefix(bytes.TrimSpace(raw.Forwarding), []byte("[")) {
+		return Spec{}, errors.New(
+			"a payload supports exactly one forwarding; generate one payload per destination instead",
+		)
+	}
+
+	var spec Spec
+
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to decode spec file: %w", err)
+	}
+
+	for key := range spec.Metadata {
+		if err = ValidateMetadataKey(key); err != nil {
+			return Spec{}, fmt.Errorf("invalid spec metadata: %w", err)
+		}
+	}
+
+	return spec, nil
+}
+
+// ActionNotes returns the notes of the actions described by the spec, by action index.
+func (s Spec) ActionNotes() []string {
+	notes := make([]string, 0, len(s.Actions))
+	for _, a := range s.Actions {
+		notes = append(notes, a.Note)
+	}
+
+	return notes
+}
+
+// Build creates the actions and forwarding described by the spec
+// and returns the encoded payload.
+func (s Spec) Build(opts Options) (string, error) {
+	actions := make([]*core.Action, 0, len(s.Actions))
+	for i, a := range s.Actions {
+		act, err := a.build()
+		if err != nil {
+			return "", fmt.Errorf("invalid action %d: %w", i, err)
+		}
+
+		actions = append(actions, act)
+	}
+
+	fwd, err := s.Forwarding.build(opts)
+	if err != nil {
+		return "", fmt.Errorf("invalid forwarding: %w", err)
+	}
+
+	return BuildPayload(fwd, actions)
+}
+
+func (a ActionSpec) build() (*core.Action, error) {
+	id, err := core.NewActionIDFromString(a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch id {
+	case core.ACTION_FEE:
+		if a.Fee == nil {
+			return nil, errors.New("fee attributes are required")
+		}
+
+		return NewFeeAction(a.Fee.Recipient, a.Fee.BasisPoints)
+	default:
+		return nil, fmt.Errorf("action %s is %w yet", id, ErrNotSupported)
+	}
+}
+
+func (f ForwardingSpec) build(opts Options) (*core.Forwarding, error) {
+	protocol, err := core.NewProtocolIDFromString(f.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	switch protocol {
+	case core.PROTOCOL_CCTP:
+		if f.CCTP == nil {
+			return nil, errors.New("cctp attributes are required")
+		}
+
+		if f.CCTP.MintRecipientPadding != "" {
+			padding, err := ParsePadding(f.CCTP.MintRecipientPadding)
+			if err != nil {
+				return nil, newError(ErrInvalidAddress, FieldMintRecipient, "%w", err)
+			}
+			opts.MintRecipientPadding = padding
+		}
+
+		if f.CCTP.DestinationCallerPadding != "" {
+			padding, err := ParsePadding(f.CCTP.DestinationCallerPadding)
+			if err != nil {
+				return nil, newError(ErrInvalidAddress, FieldDestinationCaller, "%w", err)
+			}
+			opts.DestinationCallerPadding = padding
+		}
+
+		return NewCCTPForwarding(
+			opts,
+			f.CCTP.DestinationDomain,
+			f.CCTP.MintRecipient,
+			f.CCTP.DestinationCaller,
+			f.CCTP.PassthroughPayload,
+		)
+	case core.PROTOCOL_INTERNAL:
+		if f.Internal == nil {
+			return nil, errors.New("internal attributes are required")
+		}
+
+		return NewInternalForwarding(f.Internal.Recipient)
+	default:
+		return nil, fmt.Errorf("protocol %s is %w yet", protocol, ErrNotSupported)
+	}
+}
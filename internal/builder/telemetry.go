@@ -0,0 +1,97 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+)
+
+// TelemetryEnv is the environment variable, that enables recording telemetry events
+// to the given target, if it is not passed as flag.
+const TelemetryEnv = "ORBGEN_TELEMETRY"
+
+// TelemetryEvent describes the kind of a generated payload for operators to understand
+// the usage of the tool. It intentionally contains no addresses, amounts or other values,
+// that could identify users or their funds.
+type TelemetryEvent struct {
+	Time time.Time `json:"time"`
+	// Mode is the way the payload was generated, e.g. "interactive" or "spec".
+	Mode              string   `json:"mode"`
+	Protocol          string   `json:"protocol"`
+	DestinationDomain *uint32  `json:"destination_domain,omitempty"`
+	Actions           []string `json:"actions"`
+}
+
+// NewTelemetryEvent returns the telemetry event for the given payload.
+func NewTelemetryEvent(mode, payload string) (TelemetryEvent, error) {
+	wrapper, err := DecodePayload(payload)
+	if err != nil {
+		return TelemetryEvent{}, err
+	}
+
+	event := TelemetryEvent{
+		Time:     time.Now().UTC(),
+		Mode:     mode,
+		Protocol: wrapper.Orbiter.Forwarding.ProtocolId.String(),
+		Actions:  make([]string, 0, len(wrapper.Orbiter.PreActions)),
+	}
+
+	for _, act := range wrapper.Orbiter.PreActions {
+		event.Actions = append(event.Actions, act.Id.String())
+	}
+
+	attr, err := wrapper.Orbiter.Forwarding.CachedAttributes()
+	if err != nil {
+		return TelemetryEvent{}, err
+	}
+
+	if cctp, ok := attr.(*forwarding.CCTPAttributes); ok {
+		event.DestinationDomain = &cctp.DestinationDomain
+	}
+
+	return event, nil
+}
+
+// RecordTelemetry writes the event as a single JSON line to the given target,
+// which is either a unix socket or named pipe, or a file that the event is appended to.
+func RecordTelemetry(target string, event TelemetryEvent) error {
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry event: %w", err)
+	}
+
+	if info, err := os.Stat(target); err == nil &&
+		info.Mode()&(os.ModeSocket|os.ModeNamedPipe) != 0 {
+		return WriteToEndpoint(target, string(bz))
+	}
+
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open telemetry file: %w", err)
+	}
+
+	_, err = f.Write(append(bz, '\n'))
+
+	return errors.Join(err, f.Close())
+}
@@ -0,0 +1,118 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+// TransferTxConfig contains the parameters of the ICS-20 transfer,
+// that carries the payload to the orbiter module on Noble.
+type TransferTxConfig struct {
+	// Sender is the address of the sender on the source chain.
+	Sender string
+	// Amount is the coin to transfer (e.g. 1000000uusdc).
+	Amount string
+	// SourceChannel is the channel on the source chain, that is connected to Noble.
+	SourceChannel string
+	// Timeout is the duration after which the transfer times out.
+	Timeout time.Duration
+}
+
+// BuildTransferTx wraps the given payload into the memo of an ICS-20 transfer
+// to the orbiter module account and returns the unsigned transaction as JSON.
+func BuildTransferTx(payload string, cfg TransferTxConfig) (string, error) {
+	encCfg, txBuilder, err := newTransferTx(payload, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	txBz, err := encCfg.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	return string(txBz), nil
+}
+
+// newTransferTx returns the builder of the unsigned transfer transaction
+// for the given payload, together with the encoding config to encode it.
+func newTransferTx(
+	payload string,
+	cfg TransferTxConfig,
+) (moduletestutil.TestEncodingConfig, client.TxBuilder, error) {
+	encCfg := testutil.MakeTestEncodingConfig(testutil.Prefix)
+	transfertypes.RegisterInterfaces(encCfg.InterfaceRegistry)
+
+	if cfg.Sender == "" {
+		return encCfg, nil, errors.New("transfer sender is required")
+	}
+
+	if cfg.Timeout <= 0 {
+		return encCfg, nil, errors.New("transfer timeout must be positive")
+	}
+
+	token, err := sdk.ParseCoinNormalized(cfg.Amount)
+	if err != nil {
+		return encCfg, nil, fmt.Errorf("invalid transfer amount: %w", err)
+	}
+
+	msg := transfertypes.NewMsgTransfer(
+		transfertypes.PortID,
+		cfg.SourceChannel,
+		token,
+		cfg.Sender,
+		sdk.MustBech32ifyAddressBytes(testutil.Prefix, core.ModuleAddress),
+		clienttypes.ZeroHeight(),
+		uint64(time.Now().Add(cfg.Timeout).UnixNano()), //nolint:gosec // time is after epoch
+		payload,
+	)
+
+	// NOTE: The sender is an address on the source chain, which can use any bech32 prefix.
+	// It is therefore decoded separately and the remaining fields are validated with the
+	// sender converted to the configured prefix.
+	_, senderBz, err := bech32.DecodeAndConvert(cfg.Sender)
+	if err != nil {
+		return encCfg, nil, fmt.Errorf("invalid transfer sender: %w", err)
+	}
+
+	validationMsg := *msg
+	validationMsg.Sender = sdk.AccAddress(senderBz).String()
+	if err = validationMsg.ValidateBasic(); err != nil {
+		return encCfg, nil, fmt.Errorf("invalid transfer message: %w", err)
+	}
+
+	txBuilder := encCfg.TxConfig.NewTxBuilder()
+	if err = txBuilder.SetMsgs(msg); err != nil {
+		return encCfg, nil, fmt.Errorf("failed to set transfer message: %w", err)
+	}
+
+	return encCfg, txBuilder, nil
+}
@@ -0,0 +1,87 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// ValidationResult is the outcome of validating a single part of a spec.
+type ValidationResult struct {
+	// Path identifies the validated part of the spec, e.g. actions[0].
+	Path string `json:"path"`
+	// ID is the action or protocol identifier of the validated part.
+	ID    string `json:"id,omitempty"`
+	Valid bool   `json:"valid"`
+	// Field is the input field, that the error pertains to, if known.
+	Field string `json:"field,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Validate runs all checks of the actions and forwarding described by the spec
+// without generating the payload, and returns one result per part.
+// If all parts are valid, the assembled payload is validated as well.
+func (s Spec) Validate(opts Options) []ValidationResult {
+	results := make([]ValidationResult, 0, len(s.Actions)+2)
+	valid := true
+
+	actions := make([]*core.Action, 0, len(s.Actions))
+	for i, a := range s.Actions {
+		act, err := a.build()
+		if err == nil {
+			actions = append(actions, act)
+		}
+
+		result := newValidationResult(fmt.Sprintf("actions[%d]", i), a.ID, err)
+		valid = valid && result.Valid
+		results = append(results, result)
+	}
+
+	fwd, err := s.Forwarding.build(opts)
+	result := newValidationResult("forwarding", s.Forwarding.Protocol, err)
+	valid = valid && result.Valid
+	results = append(results, result)
+
+	if valid {
+		_, err = BuildPayload(fwd, actions)
+		results = append(results, newValidationResult("payload", "", err))
+	}
+
+	return results
+}
+
+// newValidationResult returns the result for the given error,
+// which is annotated with the field of typed builder errors.
+func newValidationResult(path, id string, err error) ValidationResult {
+	result := ValidationResult{Path: path, ID: id, Valid: err == nil}
+	if err == nil {
+		return result
+	}
+
+	result.Error = err.Error()
+
+	var builderErr *Error
+	if errors.As(err, &builderErr) {
+		result.Field = builderErr.Field
+	}
+
+	return result
+}
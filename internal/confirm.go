@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// confirmDialog is a yes/no question, that is shown on top of the current screen.
+// While it is open, all key presses are handled by the dialog.
+type confirmDialog struct {
+	question string
+	// onAnswer is called with the model, after the dialog was closed,
+	// and the answer of the user.
+	onAnswer func(m Model, confirmed bool) (tea.Model, tea.Cmd)
+}
+
+// openConfirm shows the given question and calls onAnswer with the user's choice.
+func (m Model) openConfirm(
+	question string,
+	onAnswer func(m Model, confirmed bool) (tea.Model, tea.Cmd),
+) Model {
+	m.confirm = &confirmDialog{question: question, onAnswer: onAnswer}
+
+	return m
+}
+
+// writeConfirm renders the open dialog below the current screen.
+func (m Model) writeConfirm(s *strings.Builder) {
+	if m.confirm == nil {
+		return
+	}
+
+	s.WriteString("\n\n")
+	s.WriteString(m.styles.dialog.Render(
+		m.confirm.question + "\n\n" + m.styles.hint.Render("y/Enter to confirm, n/Esc to cancel"),
+	))
+}
+
+// updateConfirm answers the open dialog with the pressed key.
+// Other keys than the answers are ignored, except for Ctrl+C to quit.
+func (m Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var confirmed bool
+	switch msg.String() {
+	case "y", "Y", "enter":
+		confirmed = true
+	case "n", "N", "esc":
+		confirmed = false
+	case "ctrl+c":
+		return m, tea.Quit
+	default:
+		return m, nil
+	}
+
+	dialog := m.confirm
+	m.confirm = nil
+
+	return dialog.onAnswer(m, confirmed)
+}
+
+// confirmQuit asks the user to confirm quitting without generating a payload.
+func (m Model) confirmQuit() Model {
+	return m.openConfirm(
+		"Quit without generating a payload?",
+		func(m Model, confirmed bool) (tea.Model, tea.Cmd) {
+			if confirmed {
+				return m, tea.Quit
+			}
+
+			return m, nil
+		},
+	)
+}
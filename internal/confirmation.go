@@ -0,0 +1,342 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/noble-assets/orbiter/types/controller/action"
+	"github.com/noble-assets/orbiter/types/controller/forwarding"
+	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+// reviewSection is a section of the confirmation screen, that can be focused to edit it.
+type reviewSection int
+
+const (
+	reviewNone reviewSection = iota
+	reviewActions
+	reviewForwarding
+)
+
+func (m Model) writeConfirmation(s *strings.Builder) {
+	s.WriteString(m.styles.title.Render("Confirm Payload Contents"))
+	s.WriteString("\n\n")
+	s.WriteString("Please verify that the inputs were interpreted as intended:\n\n")
+
+	if m.showRawPayload {
+		m.writeRawPayload(s)
+		s.WriteString(
+			"\nEnter to build the payload, P to show the summary, Esc to go back, Ctrl+C to quit",
+		)
+
+		return
+	}
+
+	if m.showHexDump {
+		m.writeHexDump(s)
+		s.WriteString("\nEnter to build the payload, Up/Down to scroll, X to show the summary,\n")
+		s.WriteString("Esc to go back, Ctrl+C to quit")
+
+		return
+	}
+
+	m.writeReviewHeading(s, "Actions", reviewActions)
+	if len(m.actions) == 0 {
+		s.WriteString("  none\n")
+	}
+	for i, act := range m.actions {
+		fmt.Fprintf(s, "  %d. %s\n", i+1, act.Id.String())
+		for _, line := range describeAction(act) {
+			s.WriteString("     " + line + "\n")
+		}
+		if note := m.actionNotes[act]; note != "" {
+			s.WriteString("     Note: " + note + "\n")
+		}
+	}
+
+	s.WriteString("\n")
+	m.writeReviewHeading(s, "Forwarding", reviewForwarding)
+	fmt.Fprintf(s, "  %s\n", m.forwarding.ProtocolId.String())
+	for _, line := range describeForwarding(m.opts, m.forwarding) {
+		s.WriteString("     " + line + "\n")
+	}
+
+	if m.reviewFocus == reviewNone {
+		s.WriteString("\nEnter to build the payload, Tab to select a section to edit,\n")
+	} else {
+		s.WriteString("\nEnter to edit the selected section, Tab to select the next one,\n")
+	}
+	s.WriteString("P to show the raw payload, X to show a hex dump, Esc to go back, Ctrl+C to quit")
+}
+
+// writeReviewHeading renders the heading of a section of the confirmation screen,
+// which is emphasized and marked while the section is focused.
+func (m Model) writeReviewHeading(s *strings.Builder, heading string, section reviewSection) {
+	if m.reviewFocus == section {
+		s.WriteString(m.styles.emphasis.Render("> " + heading + " (Enter to edit)"))
+	} else {
+		s.WriteString(m.styles.title.Render(heading))
+	}
+	s.WriteString("\n")
+}
+
+// cycleReviewFocus moves the focus to the next or previous section of the confirmation
+// screen. The cycle includes no focus, in which Enter builds the payload.
+func (m Model) cycleReviewFocus(forward bool) Model {
+	if m.showRawPayload || m.showHexDump {
+		return m
+	}
+
+	// NOTE: the sections are numbered consecutively, starting with no focus.
+	const sections = int(reviewForwarding) + 1
+	step := 1
+	if !forward {
+		step = sections - 1
+	}
+
+	m.reviewFocus = reviewSection((int(m.reviewFocus) + step) % sections)
+
+	return m
+}
+
+// editReviewSection returns to the screen, on which the focused section was configured.
+// The confirmed forwarding is kept while editing the actions, so that the payload is
+// confirmed again once no more actions are added.
+func (m Model) editReviewSection() (tea.Model, tea.Cmd) {
+	switch m.reviewFocus {
+	case reviewActions:
+		m.presetForwarding = m.forwarding
+		m.forwarding = nil
+
+		return m.initActionSelection(), nil
+	case reviewForwarding:
+		if m.opts.Imported != nil {
+			m.err = errors.New("the imported forwarding cannot be edited")
+
+			return m, nil
+		}
+
+		m.state = forwardingInput
+		m.forwarding = nil
+
+		return m.focusInput(m.forwardingInputs, 0)
+	case reviewNone:
+		// Nothing to edit
+	}
+
+	return m, nil
+}
+
+// writeRawPayload writes the encoded payload, as it will be printed on exit.
+func (m Model) writeRawPayload(s *strings.Builder) {
+	s.WriteString(m.styles.title.Render("Raw Payload"))
+	s.WriteString("\n")
+
+	if m.reviewPayloadErr != nil {
+		s.WriteString(m.styles.error.Render("failed to build payload: " + m.reviewPayloadErr.Error()))
+		s.WriteString("\n")
+
+		return
+	}
+
+	style := lipgloss.NewStyle()
+	if m.windowWidth > 0 {
+		style = style.Width(m.windowWidth)
+	}
+
+	s.WriteString(style.Render(m.reviewPayload))
+	s.WriteString("\n")
+}
+
+// toggleRawPayload switches the confirmation screen between
+// the human-readable summary and the raw encoded payload.
+func (m Model) toggleRawPayload() Model {
+	m.showRawPayload = !m.showRawPayload
+	m.showHexDump = false
+	m.reviewFocus = reviewNone
+
+	return m
+}
+
+// initConfirmation shows the parsed payload contents before building the payload.
+func (m Model) initConfirmation(fwd *core.Forwarding) Model {
+	m.forwarding = fwd
+	m.reviewPayload, m.reviewPayloadErr = builder.BuildPayload(fwd, m.actions)
+	m.err = nil
+	m.showRawPayload = false
+	m.showHexDump = false
+	m.reviewFocus = reviewNone
+	m.state = payloadConfirmation
+
+	return m
+}
+
+// processConfirmation builds the final payload from the confirmed contents,
+// or lets the user select the output format first if enabled.
+// If a section is focused, it is edited instead.
+func (m Model) processConfirmation() (tea.Model, tea.Cmd) {
+	if m.reviewFocus != reviewNone {
+		return m.editReviewSection()
+	}
+
+	if m.opts.SelectFormat {
+		return m.initFormatSelection(), nil
+	}
+
+	return m.buildPayload()
+}
+
+// buildPayload builds the final payload and quits the program.
+func (m Model) buildPayload() (tea.Model, tea.Cmd) {
+	var err error
+
+	m.payload, err = builder.BuildPayload(m.forwarding, m.actions)
+	if err != nil {
+		m.err = fmt.Errorf("failed to build finalPayload: %w", err)
+
+		return m, nil
+	}
+
+	return m, tea.Quit
+}
+
+// cancelConfirmation returns to the inputs of the selected forwarding,
+// which still contain the previously entered values. If the forwarding was
+// imported or configured first, it returns to the action selection instead.
+func (m Model) cancelConfirmation() Model {
+	m.forwarding = nil
+	if m.presetForwarding != nil {
+		return m.initActionSelection()
+	}
+
+	m.state = forwardingInput
+
+	return m
+}
+
+// describeAction returns the human-readable description of the action attributes.
+func describeAction(act *core.Action) []string {
+	attr, err := act.CachedAttributes()
+	if err != nil {
+		return []string{"invalid attributes: " + err.Error()}
+	}
+
+	switch a := attr.(type) {
+	case *action.FeeAttributes:
+		lines := make([]string, 0, len(a.FeesInfo))
+		for _, info := range a.FeesInfo {
+			lines = append(lines, fmt.Sprintf(
+				"%d basis points (%s) to %s",
+				info.BasisPoints,
+				formatBasisPoints(info.BasisPoints),
+				info.Recipient,
+			))
+		}
+
+		return lines
+	default:
+		return []string{fmt.Sprintf("%T", attr)}
+	}
+}
+
+// describeForwarding returns the human-readable description of the forwarding attributes.
+// If a contract ABI is configured, the passthrough payload is shown as decoded call.
+func describeForwarding(opts builder.Options, fwd *core.Forwarding) []string {
+	attr, err := fwd.CachedAttributes()
+	if err != nil {
+		return []string{"invalid attributes: " + err.Error()}
+	}
+
+	switch a := attr.(type) {
+	case *forwarding.CCTPAttributes:
+		destCaller := "not set"
+		if len(a.DestinationCaller) > 0 {
+			destCaller = formatCCTPAddress(a.DestinationDomain, a.DestinationCaller)
+			name := builder.KnownCallerName(opts, a.DestinationDomain, a.DestinationCaller)
+			if name != "" {
+				destCaller += " (" + name + ")"
+			}
+		}
+
+		// NOTE: an empty passthrough is stated explicitly,
+		// so that it is not mistaken for a dropped value.
+		passthrough := "No passthrough payload"
+		if len(fwd.PassthroughPayload) > 0 {
+			passthrough = fmt.Sprintf("Passthrough payload: %d bytes", len(fwd.PassthroughPayload))
+		}
+
+		lines := []string{
+			fmt.Sprintf(
+				"Destination domain: %d (%s)",
+				a.DestinationDomain,
+				builder.CCTPDomainName(a.DestinationDomain),
+			),
+			"Mint recipient: " + formatCCTPAddress(a.DestinationDomain, a.MintRecipient),
+			"Destination caller: " + destCaller,
+			passthrough,
+		}
+		if len(fwd.PassthroughPayload) > 0 {
+			lines = append(
+				lines,
+				"  base64: "+base64.StdEncoding.EncodeToString(fwd.PassthroughPayload),
+				"  hex:    "+hexutil.Encode(fwd.PassthroughPayload),
+			)
+		}
+		if len(fwd.PassthroughPayload) > 0 && opts.PassthroughABI != nil {
+			call, err := builder.DecodeCalldata(opts.PassthroughABI, fwd.PassthroughPayload)
+			if err != nil {
+				call = "does not match the contract ABI: " + err.Error()
+			}
+			lines = append(lines, "  call:   "+call)
+		}
+
+		return lines
+	case *forwarding.InternalAttributes:
+		return []string{"Recipient: " + a.Recipient}
+	default:
+		return []string{fmt.Sprintf("%T", attr)}
+	}
+}
+
+// formatCCTPAddress returns the hex encoding of the given 32 byte address.
+// For EVM domains, left-padded 20 byte addresses are shown as checksummed EVM address.
+func formatCCTPAddress(domain uint32, address []byte) string {
+	d, found := builder.LookupCCTPDomain(domain)
+	if found && d.EVM && len(address) == 32 &&
+		bytes.Equal(address[:12], make([]byte, 12)) {
+		return common.BytesToAddress(address[12:]).Hex()
+	}
+
+	return hexutil.Encode(address)
+}
+
+// formatBasisPoints returns the percentage represented by the given basis points.
+func formatBasisPoints(basisPoints uint32) string {
+	return fmt.Sprintf("%d.%02d%%", basisPoints/100, basisPoints%100)
+}
@@ -0,0 +1,52 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import "github.com/charmbracelet/bubbles/textinput"
+
+// exampleValue returns a valid example value for the input at the given index
+// in the current state. An empty string is returned if there is no example.
+func (m Model) exampleValue(index int) string {
+	switch m.state {
+	case actionInput:
+		if d, ok := lookupActionDescriptor(m.selectedAction); ok && d.example != nil {
+			return d.example(index)
+		}
+	case forwardingInput:
+		if d, ok := lookupForwardingDescriptor(m.selectedProtocol); ok && d.example != nil {
+			return d.example(index)
+		}
+	default:
+		// Only input states have example values
+	}
+
+	return ""
+}
+
+// fillExample sets the focused input to an example value,
+// so that new users can see a complete working payload.
+func (m Model) fillExample(inputs []textinput.Model) {
+	if m.focusIndex < 0 || m.focusIndex >= len(inputs) {
+		return
+	}
+
+	if example := m.exampleValue(m.focusIndex); example != "" {
+		inputs[m.focusIndex].SetValue(example)
+		inputs[m.focusIndex].CursorEnd()
+	}
+}
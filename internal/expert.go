@@ -0,0 +1,307 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+// feeInputCount is the number of inputs of each fee action in the expert mode.
+const feeInputCount = 3
+
+// writeExpertInput renders the forwarding and all fee actions on a single screen.
+func (m Model) writeExpertInput(s *strings.Builder) {
+	s.WriteString(m.styles.title.Render("Orbiter Payload Generator"))
+	s.WriteString("\n\n")
+	s.WriteString(
+		"Configure the forwarding and optional fee actions, then submit them at once.\n\n",
+	)
+
+	s.WriteString(m.styles.emphasis.Render("Forwarding: " + m.selectedProtocol.String()))
+	s.WriteString("\n")
+	for i, input := range m.forwardingInputs {
+		m.writeExpertField(s, input, i)
+		if m.selectedProtocol == core.PROTOCOL_CCTP {
+			switch i {
+			case 0:
+				m.writeDomainHint(s, input.Value())
+			case 1:
+				m.writeValidationHint(s, i)
+				m.writePaddingHint(s, i)
+			case 2:
+				m.writeAutoFilledHint(s, input.Value())
+				m.writeKnownCallersHint(s, m.forwardingInputs[0].Value(), input.Value())
+				m.writePaddingHint(s, i)
+			}
+		}
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.styles.emphasis.Render("Fee Actions"))
+	s.WriteString("\n")
+	if len(m.actionInputs) == 0 {
+		s.WriteString(m.styles.hint.Render("No fee actions; press Ctrl+N to add one"))
+		s.WriteString("\n")
+	}
+
+	for i, input := range m.actionInputs {
+		if i%feeInputCount == 0 {
+			fmt.Fprintf(s, "%d.\n", i/feeInputCount+1)
+		}
+
+		m.writeExpertField(s, input, len(m.forwardingInputs)+i)
+		if i%feeInputCount == 1 {
+			m.writeBasisPointsHint(s, input.Value())
+		}
+	}
+
+	s.WriteString("\nUse Tab/Shift+Tab to navigate fields, [ and ] to cycle common CCTP domains,\n")
+	s.WriteString("Ctrl+P to switch the protocol, ")
+	s.WriteString("Ctrl+N to add a fee action, Ctrl+X to remove the focused one,\n")
+	s.WriteString("Enter to submit, Ctrl+C to quit")
+}
+
+// initExpertInput shows all inputs on a single screen, starting with the first
+// implemented protocol and no fee actions.
+//
+// NOTE: the expert mode is built from the textinput models of the wizard instead of a huh form,
+// so that it reuses their hints, the deferred validations and the keyring selection.
+func (m Model) initExpertInput() Model {
+	for _, d := range forwardingDescriptors() {
+		if d.implemented {
+			return m.selectExpertProtocol(d)
+		}
+	}
+
+	return m
+}
+
+// selectExpertProtocol replaces the forwarding inputs with the inputs of the given protocol,
+// keeping the fee actions, and focuses the first forwarding input.
+func (m Model) selectExpertProtocol(d forwardingDescriptor) Model {
+	m.selectedProtocol = d.id
+	m = d.init(m)
+	m.state = expertInput
+	m.expertErrors = nil
+	m.err = nil
+
+	// NOTE: the init function of the protocol only focuses the first forwarding input.
+	for i := range m.actionInputs {
+		m.actionInputs[i].Blur()
+	}
+
+	return m
+}
+
+// switchExpertProtocol selects the next implemented protocol.
+func (m Model) switchExpertProtocol() Model {
+	descriptors := slices.DeleteFunc(forwardingDescriptors(), func(d forwardingDescriptor) bool {
+		return !d.implemented
+	})
+
+	current := slices.IndexFunc(descriptors, func(d forwardingDescriptor) bool {
+		return d.id == m.selectedProtocol
+	})
+
+	return m.selectExpertProtocol(descriptors[(current+1)%len(descriptors)])
+}
+
+// addExpertFee appends the inputs of another fee action and focuses its recipient.
+func (m Model) addExpertFee() (Model, tea.Cmd) {
+	m.actionInputs = append(slices.Clone(m.actionInputs), m.newFeeInputs()...)
+	m.expertErrors = nil
+
+	return m.focusExpertInput(len(m.forwardingInputs) + len(m.actionInputs) - feeInputCount)
+}
+
+// removeExpertFee removes the inputs of the focused fee action.
+func (m Model) removeExpertFee() (Model, tea.Cmd) {
+	index := m.focusIndex - len(m.forwardingInputs)
+	if index < 0 {
+		return m, nil
+	}
+
+	start := index - index%feeInputCount
+	m.actionInputs = slices.Delete(slices.Clone(m.actionInputs), start, start+feeInputCount)
+	m.expertErrors = nil
+
+	return m.focusExpertInput(min(m.focusIndex, len(m.forwardingInputs)+len(m.actionInputs)-1))
+}
+
+// processExpertInput validates all inputs at once. If any of them is invalid,
+// the errors are shown next to the offending inputs and the first one is focused.
+// Otherwise, the payload is built without an additional confirmation.
+func (m Model) processExpertInput() (tea.Model, tea.Cmd) {
+	d, ok := lookupForwardingDescriptor(m.selectedProtocol)
+	if !ok {
+		m.err = fmt.Errorf("%s is %w yet", m.selectedProtocol, builder.ErrNotSupported)
+
+		return m, nil
+	}
+
+	m.expertErrors = make([]error, len(m.forwardingInputs)+len(m.actionInputs))
+	m.err = nil
+
+	m, validate, blocked := m.awaitValidations(d)
+	if blocked {
+		return m, validate
+	}
+
+	fwd, err := d.build(m)
+	if err != nil {
+		m = m.setExpertError(err, 0, d.fields)
+	}
+
+	actions := make([]*core.Action, 0, len(m.actionInputs)/feeInputCount)
+	for i := 0; i < len(m.actionInputs); i += feeInputCount {
+		feeAction, err := buildFeeAction(m.opts, m.actionInputs[i:i+feeInputCount])
+		if err != nil {
+			m = m.setExpertError(err, len(m.forwardingInputs)+i, feeFields)
+
+			continue
+		}
+
+		actions = append(actions, feeAction)
+		m = m.setActionNote(feeAction, m.actionInputs[i+2].Value())
+	}
+
+	first := slices.IndexFunc(m.expertErrors, func(err error) bool { return err != nil })
+	if first >= 0 {
+		return m.focusExpertInput(first)
+	}
+
+	if m.err != nil {
+		return m, nil
+	}
+
+	m.expertErrors = nil
+	m.actions = actions
+	m.forwarding = fwd
+
+	return m.processConfirmation()
+}
+
+// writeExpertField renders the input with the given index in the expert mode,
+// followed by its validation error if any.
+func (m Model) writeExpertField(s *strings.Builder, input textinput.Model, index int) {
+	s.WriteString(input.View() + "\n")
+
+	if index < len(m.expertErrors) && m.expertErrors[index] != nil {
+		s.WriteString(m.styles.error.Render("  " + m.expertErrors[index].Error()))
+		s.WriteString("\n")
+	}
+}
+
+// setExpertError associates the error with the input of its field,
+// where offset is the index of the first input of the given fields.
+// Errors without a known field are shown below all inputs instead.
+func (m Model) setExpertError(err error, offset int, fields []string) Model {
+	var builderErr *builder.Error
+	if errors.As(err, &builderErr) {
+		if i := slices.Index(fields, builderErr.Field); i >= 0 {
+			m.expertErrors[offset+i] = err
+
+			return m
+		}
+	}
+
+	m.err = err
+
+	return m
+}
+
+// focusExpertInput moves the focus to the input with the given index,
+// counting the forwarding inputs before the fee action inputs.
+func (m Model) focusExpertInput(index int) (Model, tea.Cmd) {
+	m.focusIndex = index
+
+	var cmd tea.Cmd
+	for i := range m.forwardingInputs {
+		if i == index {
+			cmd = m.forwardingInputs[i].Focus()
+		} else {
+			m.forwardingInputs[i].Blur()
+		}
+	}
+
+	for i := range m.actionInputs {
+		if len(m.forwardingInputs)+i == index {
+			cmd = m.actionInputs[i].Focus()
+		} else {
+			m.actionInputs[i].Blur()
+		}
+	}
+
+	return m, cmd
+}
+
+func (m Model) updateExpertInputs(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case Tab, ShiftTab, Up, Down:
+			index := m.focusIndex
+			switch msg.String() {
+			case Up, ShiftTab:
+				index = max(index-1, 0)
+			case Down, Tab:
+				index = min(index+1, len(m.forwardingInputs)+len(m.actionInputs)-1)
+			}
+
+			return m.focusExpertInput(index)
+		case LeftBracket, RightBracket:
+			if m.domainInputFocused() {
+				return m.cycleDomainPreset(msg.String() == RightBracket), nil
+			}
+		case CtrlP:
+			return m.switchExpertProtocol(), nil
+		case CtrlN:
+			return m.addExpertFee()
+		case CtrlX:
+			return m.removeExpertFee()
+		case CtrlT:
+			if m.paddingInputFocused() {
+				return m.cyclePadding(), nil
+			}
+		}
+	}
+
+	// Handle character input and blinking for all inputs
+	cmds := make([]tea.Cmd, 0, len(m.forwardingInputs)+len(m.actionInputs))
+	for i := range m.forwardingInputs {
+		var cmd tea.Cmd
+		m.forwardingInputs[i], cmd = m.forwardingInputs[i].Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	for i := range m.actionInputs {
+		var cmd tea.Cmd
+		m.actionInputs[i], cmd = m.actionInputs[i].Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,91 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+func (m Model) writeFormatSelection(s *strings.Builder) {
+	s.WriteString(m.styles.title.Render("Select Output Format"))
+	s.WriteString("\n\n")
+	s.WriteString("Choose how the payload is printed when exiting.\n\n")
+
+	s.WriteString(m.list.View())
+	s.WriteString("\nEnter to print the payload, Esc to go back")
+}
+
+// initFormatSelection lists the output formats, with the previously used format selected.
+func (m Model) initFormatSelection() Model {
+	formatItems := make([]list.Item, 0, len(builder.Formats))
+	for _, f := range builder.Formats {
+		formatItems = append(formatItems, item{
+			title:       string(f),
+			desc:        f.Description(),
+			implemented: true,
+		})
+	}
+
+	l := list.New(formatItems, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "Select an output format:"
+	if i := slices.Index(builder.Formats, m.opts.OutputFormat); i >= 0 {
+		l.Select(i)
+	}
+
+	m.list = m.resizeList(l)
+	m.err = nil
+	m.state = formatSelection
+
+	return m
+}
+
+// processFormatSelection builds the payload to be printed in the selected format.
+func (m Model) processFormatSelection() (tea.Model, tea.Cmd) {
+	selected, ok := m.list.SelectedItem().(item)
+	if !ok {
+		m.err = fmt.Errorf("failed to cast list item to item; got: %T", m.list.SelectedItem())
+
+		return m, nil
+	}
+
+	m.format = builder.Format(selected.title)
+
+	return m.buildPayload()
+}
+
+// cancelFormatSelection returns to the confirmation of the payload contents,
+// or to the inputs in the expert mode, which has no confirmation step.
+func (m Model) cancelFormatSelection() Model {
+	if m.opts.Expert {
+		m.state = expertInput
+		m.forwarding = nil
+
+		return m
+	}
+
+	m.state = payloadConfirmation
+
+	return m
+}
@@ -18,9 +18,8 @@
 package internal
 
 import (
-	"encoding/base64"
-	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -31,41 +30,80 @@ import (
 	"github.com/noble-assets/orbiter/testutil"
 	"github.com/noble-assets/orbiter/types/controller/forwarding"
 	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/noble-assets/orbgen/internal/builder"
 )
 
+// domainPresets contains the most common CCTP destination domains,
+// which can be cycled through on the domain input: Ethereum, Base and Arbitrum.
+var domainPresets = []uint32{0, 6, 3}
+
+// genericMintRecipientPlaceholder is the placeholder of the mint recipient input,
+// while no known domain is entered.
+const genericMintRecipientPlaceholder = "Mint recipient (hex with '0x', bech32 or base64; put 'r' for random)"
+
+// cctpFields contains the builder fields of the CCTP forwarding inputs, in order.
+var cctpFields = []string{
+	builder.FieldDestinationDomain,
+	builder.FieldMintRecipient,
+	builder.FieldDestinationCaller,
+	builder.FieldPassthrough,
+}
+
+// internalFields contains the builder fields of the internal forwarding inputs, in order.
+var internalFields = []string{builder.FieldRecipient}
+
 func (m Model) writeForwardingSelection(s *strings.Builder) {
 	// Header
-	s.WriteString(bold.Render("Select Forwarding Protocol"))
+	s.WriteString(m.styles.title.Render(m.messages.forwardingTitle))
 	s.WriteString("\n\n")
 
 	// Explanation
-	s.WriteString("Now choose how to forward your transaction to the destination chain.\n")
-	s.WriteString("Each protocol supports different chains and tokens:\n\n")
+	s.WriteString(m.messages.forwardingExplanation)
 
 	// List
 	s.WriteString(m.list.View())
 }
 
 func (m Model) writeCCTPForwardingSelection(s *strings.Builder) {
-	s.WriteString(bold.Render("Configure CCTP Forwarding"))
+	s.WriteString(m.styles.title.Render("Configure CCTP Forwarding"))
 	s.WriteString("\n\n")
 	s.WriteString("CCTP enables USDC transfers across chains. Configure the destination details:\n")
 	s.WriteString(
 		"• Domain: Chain identifier (0=Ethereum, 1=Avalanche, 2=OP, 3=Arbitrum, 6=Base)\n",
 	)
 	s.WriteString("• Mint Recipient: Address that receives USDC on destination\n")
+	if m.opts.ENSRPC != "" {
+		s.WriteString("  (ENS names like name.eth are resolved to their address)\n")
+	}
 	s.WriteString("• Destination Caller: Address that can call functions on destination\n")
-	s.WriteString("• Passthrough Payload: Additional data to pass through (optional)\n\n")
+	s.WriteString("  (put 'self' to use the mint recipient)\n")
+	s.WriteString(
+		"• Passthrough Payload: Additional data to pass through (optional; @path reads a file)\n\n",
+	)
 
-	for _, input := range m.forwardingInputs {
+	for i, input := range m.forwardingInputs {
 		s.WriteString(input.View() + "\n")
+		switch i {
+		case 0:
+			m.writeDomainHint(s, input.Value())
+		case 1:
+			m.writeValidationHint(s, i)
+			m.writePaddingHint(s, i)
+		case 2:
+			m.writeAutoFilledHint(s, input.Value())
+			m.writeKnownCallersHint(s, m.forwardingInputs[0].Value(), input.Value())
+			m.writePaddingHint(s, i)
+		}
 	}
 
-	s.WriteString("\nUse Tab/Shift+Tab to navigate fields, Enter to create payload, Ctrl+C to quit")
+	s.WriteString("\nUse Tab/Shift+Tab to navigate fields, [ and ] to cycle common domains,\n")
+	s.WriteString("Ctrl+R to reroll random values, Ctrl+E to fill in an example,\n")
+	s.WriteString("Enter to review payload, Ctrl+C to quit")
 }
 
 func (m Model) writeInternalForwardingSelection(s *strings.Builder) {
-	s.WriteString(bold.Render("Configure Internal Transfer"))
+	s.WriteString(m.styles.title.Render("Configure Internal Transfer"))
 	s.WriteString("\n\n")
 	s.WriteString("Internal transfers forward incoming tokens to an address on the Noble chain.\n")
 	s.WriteString("• Recipient: The bech32 Noble address to receive the tokens\n\n")
@@ -74,33 +112,30 @@ func (m Model) writeInternalForwardingSelection(s *strings.Builder) {
 		s.WriteString(input.View() + "\n")
 	}
 
-	s.WriteString("\nEnter to create payload, Ctrl+C to quit")
+	m.writeKeyringHint(s)
+	s.WriteString("\nCtrl+E to fill in an example, Enter to review payload, Ctrl+C to quit")
 }
 
 func (m Model) initForwardingSelection() Model {
-	forwardingItems := []list.Item{
-		item{
-			title: core.PROTOCOL_CCTP.String(),
-			desc:  "Circle's Cross-Chain Transfer Protocol (USDC transfers)",
-		},
-		item{
-			title: core.PROTOCOL_IBC.String(),
-			desc:  "Inter-Blockchain Communication (Cosmos ecosystem)",
-		},
-		item{title: core.PROTOCOL_HYPERLANE.String(), desc: "Hyperlane interchain protocol"},
-		item{title: core.PROTOCOL_INTERNAL.String(), desc: "Internal transfer on Noble"},
-	}
-
-	l := list.New(forwardingItems, list.NewDefaultDelegate(), 0, 0)
-	l.Title = "Select a protocol:"
+	descriptors := forwardingDescriptors()
+	forwardingItems := make([]item, 0, len(descriptors))
+	for _, d := range descriptors {
+		desc, found := m.messages.protocolDescriptions[d.id]
+		if !found {
+			desc = d.desc
+		}
 
-	// Apply stored window dimensions if we have them
-	if m.windowWidth > 0 && m.windowHeight > 0 {
-		l.SetWidth(m.windowWidth)
-		l.SetHeight(m.windowHeight - 3)
+		forwardingItems = append(forwardingItems, item{
+			title:       d.id.String(),
+			desc:        desc,
+			implemented: d.implemented,
+		})
 	}
 
-	m.list = l
+	l := list.New(m.listItems(forwardingItems...), list.NewDefaultDelegate(), 0, 0)
+	l.Title = m.messages.protocolListTitle
+
+	m.list = m.resizeList(l)
 	m.state = forwardingSelection
 
 	return m
@@ -115,28 +150,38 @@ func (m Model) initCCTPForwardingInput() Model {
 	inputs[0].Width = 30
 
 	inputs[1] = textinput.New()
-	inputs[1].Placeholder = "Mint recipient (prefix with '0x' for Hex input; otherwise base64 is assumed; put 'r' for random)"
+	inputs[1].Placeholder = genericMintRecipientPlaceholder
 	inputs[1].CharLimit = 128
 	inputs[1].Width = 70
 
 	inputs[2] = textinput.New()
-	inputs[2].Placeholder = "Destination caller (prefix with '0x' for Hex input; otherwise base64 is assumed; put 'r' for random)"
+	inputs[2].Placeholder = "Destination caller (hex with '0x', bech32 or base64; put 'r' for random)"
 	inputs[2].CharLimit = 128
 	inputs[2].Width = 70
 
 	inputs[3] = textinput.New()
-	inputs[3].Placeholder = "Passthrough payload (can be left empty)"
-	inputs[3].CharLimit = 256
+	inputs[3].Placeholder = "Passthrough payload (can be left empty; prefix a file path with '@' to read it)"
+	// NOTE: the passthrough is not limited in characters but validated by its size in bytes.
+	inputs[3].CharLimit = 0
 	inputs[3].Width = 70
 
+	if domain := m.opts.Profile.DestinationDomain; domain != nil {
+		inputs[0].SetValue(strconv.FormatUint(uint64(*domain), 10))
+	}
+	inputs[1].SetValue(m.opts.Profile.MintRecipient)
+
 	m.forwardingInputs = inputs
-	m.state = cctpForwardingInput
-	focusIndex = 0
+	m.randomValues = make([]string, len(inputs))
+	m.autoFilledCaller = ""
+	m.prefilledDomain = ""
+	m.validations = nil
+	m.state = forwardingInput
+	m.focusIndex = 0
 
 	// Focus the first input
 	m.forwardingInputs[0].Focus()
 
-	return m
+	return m.applyEnteredDomain()
 }
 
 func (m Model) initInternalForwardingInput() Model {
@@ -146,10 +191,13 @@ func (m Model) initInternalForwardingInput() Model {
 	inputs[0].Placeholder = "Recipient address (bech32 Noble address)"
 	inputs[0].CharLimit = 128
 	inputs[0].Width = 70
+	inputs[0].SetValue(m.opts.Profile.Recipient)
 
 	m.forwardingInputs = inputs
-	m.state = internalForwardingInput
-	focusIndex = 0
+	m.randomValues = make([]string, len(inputs))
+	m.validations = nil
+	m.state = forwardingInput
+	m.focusIndex = 0
 
 	// Focus the first input
 	m.forwardingInputs[0].Focus()
@@ -158,185 +206,348 @@ func (m Model) initInternalForwardingInput() Model {
 }
 
 func (m Model) processCCTPForwarding() (tea.Model, tea.Cmd) {
-	domainStr := strings.TrimSpace(m.forwardingInputs[0].Value())
-	mintRecipientStr := strings.TrimSpace(m.forwardingInputs[1].Value())
-	destCallerStr := strings.TrimSpace(m.forwardingInputs[2].Value())
-	passthroughStr := strings.TrimSpace(m.forwardingInputs[3].Value())
-
-	if domainStr == "" {
+	if strings.TrimSpace(m.forwardingInputs[0].Value()) == "" {
 		return m, nil
 	}
 
-	domain, err := strconv.ParseUint(domainStr, 10, 32)
+	d, _ := lookupForwardingDescriptor(core.PROTOCOL_CCTP)
+	m, validate, blocked := m.awaitValidations(d)
+	if blocked {
+		return m, validate
+	}
+
+	cctpForwarding, err := m.buildCCTPForwarding()
 	if err != nil {
-		m.err = fmt.Errorf("invalid destination domain: %w", err)
+		return m.inputError(err, m.forwardingInputs, cctpFields)
+	}
 
-		return m, nil
+	return m.completeForwarding(cctpForwarding), nil
+}
+
+func (m Model) processInternalForwarding() (tea.Model, tea.Cmd) {
+	internalForwarding, err := m.buildInternalForwarding()
+	if err != nil {
+		return m.inputError(err, m.forwardingInputs, internalFields)
 	}
 
-	if mintRecipientStr == "" {
-		m.err = errors.New("mint recipient cannot be empty")
+	return m.completeForwarding(internalForwarding), nil
+}
+
+// completeForwarding continues with the given forwarding built from the inputs.
+// By default, the actions were selected before, so the payload is confirmed next.
+// If the forwarding is configured first, the actions are selected next instead,
+// unless the forwarding is edited after the actions were selected.
+func (m Model) completeForwarding(fwd *core.Forwarding) Model {
+	if m.presetForwarding != nil {
+		m.presetForwarding = fwd
 
-		return m, nil
+		return m.initConfirmation(fwd)
 	}
 
-	var mintRecipient []byte
-	if mintRecipientStr == "r" {
-		mintRecipient = testutil.RandomBytes(32)
-	} else {
-		mintRecipient, err = decodeHexOrBase64To32Bytes(mintRecipientStr)
-		if err != nil {
-			m.err = fmt.Errorf("invalid mint recipient: %w", err)
+	if !m.opts.ForwardingFirst {
+		return m.initConfirmation(fwd)
+	}
 
-			return m, nil
-		}
+	m.presetForwarding = fwd
+	m.err = nil
+
+	return m.initActionSelection()
+}
+
+func (m Model) updateForwardingInputs(msg tea.Msg) (Model, tea.Cmd) {
+	if len(m.forwardingInputs) == 0 {
+		return m, nil
 	}
 
-	var destCaller []byte
-	if destCallerStr == "r" {
-		destCaller = testutil.RandomBytes(32)
-	} else if destCallerStr != "" {
-		destCaller, err = decodeHexOrBase64To32Bytes(destCallerStr)
-		if err != nil {
-			m.err = fmt.Errorf("invalid destination caller: %w", err)
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case Tab, ShiftTab, Up, Down:
+			s := msg.String()
+
+			// Update focus position
+			switch s {
+			case Up, ShiftTab:
+				if m.focusIndex > 0 {
+					m.focusIndex--
+				}
+			case Down, Tab:
+				if m.focusIndex < len(m.forwardingInputs)-1 {
+					m.focusIndex++
+				}
+			}
+
+			// Update focus for all inputs
+			return m.focusInput(m.forwardingInputs, m.focusIndex)
+		case LeftBracket, RightBracket:
+			if m.domainInputFocused() {
+				return m.cycleDomainPreset(msg.String() == RightBracket), nil
+			}
+		case CtrlR:
+			m.rerollRandomInputs()
+
+			return m, nil
+		case CtrlE:
+			m.fillExample(m.forwardingInputs)
 
 			return m, nil
+		case CtrlL:
+			return m.initKeyringSelection(), nil
+		case CtrlT:
+			if m.paddingInputFocused() {
+				return m.cyclePadding(), nil
+			}
 		}
 	}
 
-	var passthroughPayload []byte
-	if passthroughStr != "" {
-		passthroughPayload = []byte(passthroughStr)
+	// Handle character input and blinking for all inputs
+	cmds := make([]tea.Cmd, len(m.forwardingInputs))
+	for i := range m.forwardingInputs {
+		m.forwardingInputs[i], cmds[i] = m.forwardingInputs[i].Update(msg)
 	}
 
-	cctpForwarding, err := forwarding.NewCCTPForwarding(
-		uint32(domain),
-		mintRecipient,
-		destCaller,
-		passthroughPayload,
-	)
+	return m, tea.Batch(cmds...)
+}
+
+// writeDomainHint shows the name of the entered CCTP domain, if it is known.
+func (m Model) writeDomainHint(s *strings.Builder, value string) {
+	domain, err := builder.ParseDomain(strings.TrimSpace(value))
 	if err != nil {
-		m.err = fmt.Errorf("failed to create CCTP forwarding: %w", err)
+		return
+	}
 
-		return m, nil
+	if d, found := builder.LookupCCTPDomain(domain); found {
+		s.WriteString(m.styles.hint.Render("  = " + d.Name))
+		s.WriteString("\n")
 	}
+}
 
-	m.forwarding = cctpForwarding
+// writeAutoFilledHint indicates that the destination caller was prefilled
+// with the default of the domain, as long as it is not changed.
+func (m Model) writeAutoFilledHint(s *strings.Builder, value string) {
+	if m.autoFilledCaller == "" || strings.TrimSpace(value) != m.autoFilledCaller {
+		return
+	}
 
-	m.payload, err = buildFinalPayload(m.forwarding, m.actions)
+	s.WriteString(m.styles.hint.Render("  (auto-filled default of the domain; can be edited)"))
+	s.WriteString("\n")
+}
+
+// writeKnownCallersHint shows the address of the known destination caller entered by name,
+// or lists the names of the known callers of the entered domain if the input is empty.
+func (m Model) writeKnownCallersHint(s *strings.Builder, domainValue, value string) {
+	domain, err := builder.ParseDomain(strings.TrimSpace(domainValue))
 	if err != nil {
-		m.err = fmt.Errorf("failed to build finalPayload: %w", err)
+		return
+	}
 
-		return m, nil
+	var hint string
+	if caller, found := builder.LookupKnownCaller(m.opts, domain, strings.TrimSpace(value)); found {
+		hint = "  = " + caller.Address
+	} else if callers := m.opts.KnownCallers[domain]; len(callers) > 0 &&
+		strings.TrimSpace(value) == "" {
+		names := make([]string, 0, len(callers))
+		for _, caller := range callers {
+			names = append(names, caller.Name)
+		}
+
+		hint = "  Known callers: " + strings.Join(names, ", ")
+	}
+
+	if hint == "" {
+		return
 	}
 
-	return m, tea.Quit
+	s.WriteString(m.styles.hint.Render(hint))
+	s.WriteString("\n")
 }
 
-func (m Model) processInternalForwarding() (tea.Model, tea.Cmd) {
-	recipientStr := strings.TrimSpace(m.forwardingInputs[0].Value())
+// applyEnteredDomain adapts the CCTP inputs to the entered domain, whenever the domain
+// changes. The mint recipient placeholder shows the address format of the domain, and the
+// destination caller input is set to the default of the domain. Callers that were entered
+// by the user are kept, while previously prefilled callers are replaced.
+func (m Model) applyEnteredDomain() Model {
+	if m.selectedProtocol != core.PROTOCOL_CCTP || len(m.forwardingInputs) < 3 {
+		return m
+	}
 
-	if recipientStr == "" {
-		m.err = errors.New("recipient address is required")
+	domainStr := strings.TrimSpace(m.forwardingInputs[0].Value())
+	if domainStr == m.prefilledDomain {
+		return m
+	}
 
-		return m, nil
+	m.prefilledDomain = domainStr
+	m.forwardingInputs[1].Placeholder = mintRecipientPlaceholder(domainStr)
+
+	caller := strings.TrimSpace(m.forwardingInputs[2].Value())
+	if caller != "" && caller != m.autoFilledCaller {
+		return m
 	}
 
-	internalForwarding, err := forwarding.NewInternalForwarding(recipientStr)
-	if err != nil {
-		m.err = fmt.Errorf("failed to create internal forwarding: %w", err)
+	var defaultCaller string
+	if domain, err := builder.ParseDomain(domainStr); err == nil {
+		defaultCaller = builder.DefaultDestinationCaller(m.opts, domain)
+	}
 
-		return m, nil
+	m.forwardingInputs[2].SetValue(defaultCaller)
+	m.autoFilledCaller = defaultCaller
+
+	return m
+}
+
+// paddingInputFocused returns whether the mint recipient or destination caller input
+// of the CCTP forwarding is focused, whose padding mode can be changed.
+func (m Model) paddingInputFocused() bool {
+	return (m.state == forwardingInput || m.state == expertInput) &&
+		m.selectedProtocol == core.PROTOCOL_CCTP &&
+		(m.focusIndex == 1 || m.focusIndex == 2)
+}
+
+// inputPadding returns the padding mode of the CCTP address input with the given index.
+func (m Model) inputPadding(index int) builder.Padding {
+	padding := m.opts.MintRecipientPadding
+	if index == 2 {
+		padding = m.opts.DestinationCallerPadding
 	}
 
-	m.forwarding = internalForwarding
+	if padding == "" {
+		return builder.PaddingAuto
+	}
 
-	m.payload, err = buildFinalPayload(m.forwarding, m.actions)
-	if err != nil {
-		m.err = fmt.Errorf("failed to build finalPayload: %w", err)
+	return padding
+}
 
-		return m, nil
+// cyclePadding selects the next padding mode for the focused CCTP address input.
+func (m Model) cyclePadding() Model {
+	padding := m.inputPadding(m.focusIndex).Next()
+	if m.focusIndex == 2 {
+		m.opts.DestinationCallerPadding = padding
+	} else {
+		m.opts.MintRecipientPadding = padding
 	}
 
-	return m, tea.Quit
+	return m
 }
 
-func (m Model) updateForwardingInputs(msg tea.Msg) tea.Cmd {
-	if len(m.forwardingInputs) == 0 {
-		return nil
+// writePaddingHint shows the padding mode of the CCTP address input with the given index,
+// if the entered address is shorter than 32 bytes, or a padding mode other than auto is selected.
+func (m Model) writePaddingHint(s *strings.Builder, index int) {
+	domain, err := builder.ParseDomain(strings.TrimSpace(m.forwardingInputs[0].Value()))
+	if err != nil {
+		return
 	}
 
-	if msg, ok := msg.(tea.KeyMsg); ok {
-		switch msg.String() {
-		case Tab, ShiftTab, Up, Down:
-			s := msg.String()
+	padding := m.inputPadding(index)
+	value := strings.TrimSpace(m.forwardingInputs[index].Value())
+	length, ambiguous := builder.AmbiguousAddressLength(domain, value)
+	if !ambiguous && padding == builder.PaddingAuto {
+		return
+	}
 
-			// Update focus position
-			switch s {
-			case Up, ShiftTab:
-				if focusIndex > 0 {
-					focusIndex--
-				}
-			case Down, Tab:
-				if focusIndex < len(m.forwardingInputs)-1 {
-					focusIndex++
-				}
-			}
+	hint := "  padding: " + string(padding)
+	if ambiguous {
+		hint = fmt.Sprintf("  %d bytes; padding: %s", length, padding)
+	}
 
-			// Update focus for all inputs
-			cmds := make([]tea.Cmd, len(m.forwardingInputs))
-			for i := range m.forwardingInputs {
-				if i == focusIndex {
-					cmds[i] = m.forwardingInputs[i].Focus()
-				} else {
-					m.forwardingInputs[i].Blur()
-				}
-			}
+	s.WriteString(m.styles.hint.Render(hint + " (Ctrl+T to change)"))
+	s.WriteString("\n")
+}
 
-			return tea.Batch(cmds...)
-		}
+// mintRecipientPlaceholder returns the placeholder of the mint recipient input
+// with an example in the address format of the given domain, or the generic
+// placeholder if the domain is unknown. The example is shortened to fit the input.
+// For Noble, which cannot be a CCTP destination, no example is given.
+//
+// NOTE: base58 is not accepted, so Solana addresses are shown in hex.
+func mintRecipientPlaceholder(domainStr string) string {
+	domain, err := builder.ParseDomain(domainStr)
+	if err != nil {
+		return genericMintRecipientPlaceholder
 	}
 
-	// Handle character input and blinking for all inputs
-	cmds := make([]tea.Cmd, len(m.forwardingInputs))
-	for i := range m.forwardingInputs {
-		m.forwardingInputs[i], cmds[i] = m.forwardingInputs[i].Update(msg)
+	d, found := builder.LookupCCTPDomain(domain)
+	switch {
+	case !found:
+		return genericMintRecipientPlaceholder
+	case domain == forwarding.CCTPNobleDomain:
+		return "Noble is not a valid CCTP destination; enter another domain"
+	case d.EVM:
+		return "Mint recipient on " + d.Name + " (EVM address, e.g. 0x742d35Cc...f44e)"
+	case d.Bech32Prefix != "":
+		return "Mint recipient on " + d.Name +
+			" (bech32 address, e.g. " + d.Bech32Prefix + "1qyz...)"
+	default:
+		return "Mint recipient on " + d.Name + " (32 byte hex address, e.g. 0x4e2a...91c0)"
 	}
+}
 
-	return tea.Batch(cmds...)
+// domainInputFocused returns whether the destination domain input
+// of the CCTP forwarding is focused.
+func (m Model) domainInputFocused() bool {
+	return (m.state == forwardingInput || m.state == expertInput) &&
+		m.selectedProtocol == core.PROTOCOL_CCTP &&
+		m.focusIndex == 0
 }
 
-// decodeHexOrBase64To32Bytes decodes a string as either a hex or base64 encoded string.
-// It returns a 32 byte slice, or an error if the input is invalid.
-func decodeHexOrBase64To32Bytes(input string) (decoded []byte, err error) {
-	if strings.HasPrefix(input, "0x") {
-		decoded, err = hexutil.Decode(input)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode hex: %w", err)
-		}
-	} else {
-		decoded, err = base64.StdEncoding.DecodeString(input)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode base64: %w", err)
+// cycleDomainPreset sets the destination domain input to the next or previous preset.
+// If the entered domain is no preset, the cycle starts at the first or last preset.
+func (m Model) cycleDomainPreset(forward bool) Model {
+	current, err := builder.ParseDomain(strings.TrimSpace(m.forwardingInputs[0].Value()))
+	index := slices.Index(domainPresets, current)
+
+	switch {
+	case err != nil || index < 0:
+		index = 0
+		if !forward {
+			index = len(domainPresets) - 1
 		}
+	case forward:
+		index = (index + 1) % len(domainPresets)
+	default:
+		index = (index + len(domainPresets) - 1) % len(domainPresets)
 	}
 
-	return leftPadIfRequired(decoded)
+	m.forwardingInputs[0].SetValue(strconv.FormatUint(uint64(domainPresets[index]), 10))
+	m.forwardingInputs[0].CursorEnd()
+
+	return m
 }
 
-// leftPadIfRequired pads a byte slice to the left with 0x00 if the length is not 32 bytes.
-func leftPadIfRequired(input []byte) ([]byte, error) {
-	inputLen := len(input)
-	if inputLen > 32 {
-		return nil, fmt.Errorf("input is too long; max 32 bytes; got: %d", inputLen)
+// buildCCTPForwarding builds the CCTP forwarding from the values of the inputs.
+func (m Model) buildCCTPForwarding() (*core.Forwarding, error) {
+	domain, err := builder.ParseDomain(strings.TrimSpace(m.forwardingInputs[0].Value()))
+	if err != nil {
+		return nil, err
 	}
 
-	if inputLen == 32 {
-		return input, nil
+	return builder.NewCCTPForwarding(
+		m.opts,
+		domain,
+		m.validatedValue(1),
+		strings.TrimSpace(m.forwardingInputs[2].Value()),
+		strings.TrimSpace(m.forwardingInputs[3].Value()),
+	)
+}
+
+// buildInternalForwarding builds the internal forwarding from the value of the recipient input.
+func (m Model) buildInternalForwarding() (*core.Forwarding, error) {
+	return builder.NewInternalForwarding(strings.TrimSpace(m.forwardingInputs[0].Value()))
+}
+
+// rerollRandomInputs replaces the values of all CCTP address inputs that are set to 'r',
+// or that still contain a value previously generated from it, with fresh random bytes.
+func (m Model) rerollRandomInputs() {
+	if m.state != forwardingInput || m.selectedProtocol != core.PROTOCOL_CCTP {
+		return
 	}
 
-	padded := make([]byte, 32)
-	copy(padded[32-len(input):], input)
+	// NOTE: only the mint recipient and destination caller support random values.
+	for _, i := range []int{1, 2} {
+		value := strings.TrimSpace(m.forwardingInputs[i].Value())
+		if value == "" || (value != "r" && value != m.randomValues[i]) {
+			continue
+		}
 
-	return padded, nil
+		m.randomValues[i] = hexutil.Encode(testutil.RandomBytes(32))
+		m.forwardingInputs[i].SetValue(m.randomValues[i])
+	}
 }
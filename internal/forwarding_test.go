@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+func TestForwardingOnlyPayloadMatchesFlags(t *testing.T) {
+	m := InitialModel(builder.Options{})
+	require.Equal(t, actionSelection, m.state)
+
+	// Selecting "No more actions" right away continues with the forwarding only.
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, forwardingSelection, m.state)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, forwardingInput, m.state)
+
+	m = update(t, m, typeText("6"))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m = update(t, m, typeText(testMintRecipient))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	require.Equal(t, payloadConfirmation, m.state)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	require.NotEmpty(t, m.GetPayload())
+
+	// The flags describe the same forwarding as positional arguments, without any actions.
+	spec, err := builder.SpecFromArgs([]string{"cctp", "6", testMintRecipient})
+	require.NoError(t, err)
+	require.Empty(t, spec.Actions)
+
+	payload, err := spec.Build(builder.Options{})
+	require.NoError(t, err)
+	require.Equal(t, m.GetPayload(), payload)
+
+	wrapper, err := builder.DecodePayload(payload)
+	require.NoError(t, err)
+	require.Empty(t, wrapper.GetOrbiter().GetPreActions())
+}
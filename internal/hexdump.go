@@ -0,0 +1,83 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// hexDumpHeightOffset is the number of lines reserved around the hex dump viewport.
+const hexDumpHeightOffset = 8
+
+// hexDumpDefaultHeight is the height of the hex dump viewport,
+// if no window dimensions were received yet.
+const hexDumpDefaultHeight = 16
+
+// hexDumpWidth is the width of a line of the hex dump, that fits 16 bytes
+// with their offset and the ASCII gutter.
+const hexDumpWidth = 78
+
+// toggleHexDump switches the confirmation screen between the human-readable summary
+// and a hex dump of the encoded payload bytes, to cross-check them against a reference.
+func (m Model) toggleHexDump() Model {
+	m.showHexDump = !m.showHexDump
+	m.showRawPayload = false
+	m.reviewFocus = reviewNone
+
+	if !m.showHexDump {
+		return m
+	}
+
+	m.hexDump = m.resizeHexDump(viewport.New(hexDumpWidth, hexDumpDefaultHeight))
+
+	if m.reviewPayloadErr != nil {
+		m.hexDump.SetContent(
+			m.styles.error.Render("failed to build payload: " + m.reviewPayloadErr.Error()),
+		)
+
+		return m
+	}
+
+	m.hexDump.SetContent(strings.TrimSuffix(hex.Dump([]byte(m.reviewPayload)), "\n"))
+
+	return m
+}
+
+// resizeHexDump fits the hex dump viewport into the stored window dimensions.
+// The viewport keeps its size if no dimensions were received yet.
+func (m Model) resizeHexDump(v viewport.Model) viewport.Model {
+	if m.windowWidth <= 0 || m.windowHeight <= 0 {
+		return v
+	}
+
+	v.Width = m.windowWidth
+	v.Height = max(m.windowHeight-hexDumpHeightOffset, 1)
+
+	return v
+}
+
+// writeHexDump writes the scrollable hex dump of the encoded payload.
+func (m Model) writeHexDump(s *strings.Builder) {
+	s.WriteString(m.styles.title.Render("Payload Hex Dump"))
+	s.WriteString("\n")
+	s.WriteString(m.hexDump.View())
+	s.WriteString("\n")
+}
@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// maxHistorySize is the maximum number of changes to the actions, that can be undone.
+const maxHistorySize = 20
+
+// actionHistory contains snapshots of the actions to undo and redo changes.
+type actionHistory struct {
+	undo [][]*core.Action
+	redo [][]*core.Action
+}
+
+// setActions replaces the actions, while recording the previous ones to be undone.
+func (m Model) setActions(actions []*core.Action) Model {
+	m.history.undo = append(slices.Clone(m.history.undo), m.actions)
+	if len(m.history.undo) > maxHistorySize {
+		m.history.undo = slices.Delete(slices.Clone(m.history.undo), 0, 1)
+	}
+	m.history.redo = nil
+	m.actions = actions
+
+	return m
+}
+
+// undoActions restores the actions before the last change.
+func (m Model) undoActions() Model {
+	if len(m.history.undo) == 0 {
+		m.err = errors.New("nothing to undo")
+
+		return m
+	}
+
+	last := len(m.history.undo) - 1
+	m.history.redo = append(slices.Clone(m.history.redo), m.actions)
+	m.actions = m.history.undo[last]
+	m.history.undo = slices.Clone(m.history.undo[:last])
+	m.err = nil
+
+	return m
+}
+
+// redoActions restores the actions of the last undone change.
+func (m Model) redoActions() Model {
+	if len(m.history.redo) == 0 {
+		m.err = errors.New("nothing to redo")
+
+		return m
+	}
+
+	last := len(m.history.redo) - 1
+	m.history.undo = append(slices.Clone(m.history.undo), m.actions)
+	m.actions = m.history.redo[last]
+	m.history.redo = slices.Clone(m.history.redo[:last])
+	m.err = nil
+
+	return m
+}
@@ -0,0 +1,44 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// idleTimeoutMsg is sent when an idle timer expires.
+// It only quits the program if there was no key press since the timer was started.
+type idleTimeoutMsg struct {
+	tag int
+}
+
+// idleTimer starts a timer for the configured idle timeout,
+// or returns nil if no idle timeout is configured.
+func (m Model) idleTimer() tea.Cmd {
+	if m.opts.IdleTimeout <= 0 {
+		return nil
+	}
+
+	tag := m.idleTag
+
+	return tea.Tick(m.opts.IdleTimeout, func(time.Time) tea.Msg {
+		return idleTimeoutMsg{tag: tag}
+	})
+}
@@ -0,0 +1,91 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+// writePayloadImport renders the input to paste a previously generated payload into.
+func (m Model) writePayloadImport(s *strings.Builder) {
+	s.WriteString(m.styles.title.Render("Import Payload"))
+	s.WriteString("\n\n")
+	s.WriteString("Paste a previously generated payload to review and extend it.\n")
+	s.WriteString("Its actions can be edited, while the forwarding is kept as is.\n\n")
+
+	s.WriteString(m.importInput.View() + "\n")
+
+	s.WriteString("\nCtrl+V to paste from the clipboard, Enter to import,\n")
+	s.WriteString("Esc to go back, Ctrl+C to quit")
+}
+
+// initPayloadImport shows the input to paste a payload into.
+// Importing is only possible before any actions or forwarding were configured,
+// so that no contents are replaced.
+func (m Model) initPayloadImport() Model {
+	if len(m.actions) > 0 || m.presetForwarding != nil {
+		m.err = errors.New("a payload can only be imported before adding any actions")
+
+		return m
+	}
+
+	input := textinput.New()
+	input.Placeholder = "Payload (e.g. {\"orbiter\":{...}})"
+	// NOTE: the payload is not limited in characters but validated when decoding it.
+	input.CharLimit = 0
+	input.Width = 70
+	input.Focus()
+
+	m.importInput = input
+	m.err = nil
+	m.state = payloadImport
+
+	return m
+}
+
+// processPayloadImport decodes the pasted payload and shows it for review,
+// from where actions can be added like for payloads imported from a file.
+// If the payload is invalid, the error is shown and the input is kept to retry.
+func (m Model) processPayloadImport() (tea.Model, tea.Cmd) {
+	wrapper, err := builder.DecodePayload(m.importInput.Value())
+	if err != nil {
+		m.err = err
+
+		return m, nil
+	}
+
+	m.opts.Imported = wrapper
+	m.actions = slices.Clone(wrapper.Orbiter.PreActions)
+	m.presetForwarding = wrapper.Orbiter.Forwarding
+
+	return m.initConfirmation(m.presetForwarding), nil
+}
+
+// cancelPayloadImport returns to the action selection without importing a payload.
+func (m Model) cancelPayloadImport() Model {
+	m.err = nil
+
+	return m.initActionSelection()
+}
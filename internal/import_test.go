@@ -0,0 +1,91 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+func TestImportPayloadAndAppendFeeAction(t *testing.T) {
+	fwd, err := builder.NewCCTPForwarding(
+		builder.Options{},
+		6,
+		testMintRecipient,
+		"0x0000000000000000000000000000000000000000000000000000000000000001",
+		"hook data",
+	)
+	require.NoError(t, err)
+
+	original, err := builder.BuildPayload(fwd, nil)
+	require.NoError(t, err)
+
+	m := update(t, InitialModel(builder.Options{}), tea.KeyMsg{Type: tea.KeyCtrlO})
+	require.Equal(t, payloadImport, m.state)
+
+	m = update(t, m, typeText(original))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	require.Equal(t, payloadConfirmation, m.state)
+
+	// Going back from the confirmation keeps the imported forwarding to append actions.
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, actionSelection, m.state)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, actionInput, m.state)
+
+	feeRecipient := testutil.NewNobleAddress()
+	m = update(t, m, typeText(feeRecipient))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m = update(t, m, typeText("100"))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	require.Equal(t, actionSelection, m.state)
+
+	// Selecting "No more actions" returns to the confirmation of the imported forwarding.
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, payloadConfirmation, m.state)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	require.NotEmpty(t, m.GetPayload())
+
+	originalWrapper, err := builder.DecodePayload(original)
+	require.NoError(t, err)
+
+	rebuilt, err := builder.DecodePayload(m.GetPayload())
+	require.NoError(t, err)
+
+	expectedBz, err := originalWrapper.Orbiter.Forwarding.Marshal()
+	require.NoError(t, err)
+
+	rebuiltBz, err := rebuilt.Orbiter.Forwarding.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, expectedBz, rebuiltBz, "expected the forwarding to be unchanged")
+
+	require.Len(t, rebuilt.Orbiter.PreActions, 1)
+	require.Equal(t, core.ACTION_FEE, rebuilt.Orbiter.PreActions[0].Id)
+}
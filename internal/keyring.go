@@ -0,0 +1,141 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+func (m Model) writeKeyringSelection(s *strings.Builder) {
+	s.WriteString(m.styles.title.Render("Select Keyring Address"))
+	s.WriteString("\n\n")
+	s.WriteString("The address of the selected key is filled into the focused input.\n\n")
+
+	s.WriteString(m.list.View())
+	s.WriteString("\nEnter to select, Esc to go back")
+}
+
+// writeKeyringHint hints at the keyring selection, if a keyring is configured.
+func (m Model) writeKeyringHint(s *strings.Builder) {
+	if m.opts.KeyringDir == "" {
+		return
+	}
+
+	s.WriteString("\n")
+	s.WriteString(
+		m.styles.hint.Render("Press Ctrl+L on an address input to select a key from the keyring"),
+	)
+	s.WriteString("\n")
+}
+
+// addressInputs returns the indices of the current inputs, that accept a Noble address.
+func (m Model) addressInputs() []int {
+	switch m.state {
+	case actionInput:
+		if d, ok := lookupActionDescriptor(m.selectedAction); ok {
+			return d.addressInputs
+		}
+	case forwardingInput:
+		if d, ok := lookupForwardingDescriptor(m.selectedProtocol); ok {
+			return d.addressInputs
+		}
+	default:
+		// Only input states contain address inputs
+	}
+
+	return nil
+}
+
+// initKeyringSelection lists the keys of the configured keyring,
+// to fill the focused address input with the address of the selected key.
+func (m Model) initKeyringSelection() Model {
+	if !slices.Contains(m.addressInputs(), m.focusIndex) {
+		m.err = errors.New("the focused input does not accept a Noble address")
+
+		return m
+	}
+
+	entries, err := builder.ListKeyring(m.opts)
+	if err != nil {
+		m.err = err
+
+		return m
+	}
+
+	if len(entries) == 0 {
+		m.err = errors.New("no keys found in the keyring")
+
+		return m
+	}
+
+	keyItems := make([]list.Item, 0, len(entries))
+	for _, entry := range entries {
+		keyItems = append(keyItems, item{
+			title:       entry.Name,
+			desc:        m.truncateAddress(entry.Address, listItemReserved),
+			value:       entry.Address,
+			implemented: true,
+		})
+	}
+
+	l := list.New(keyItems, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "Select a key:"
+
+	m.list = m.resizeList(l)
+	m.err = nil
+	m.keyringReturnState = m.state
+	m.state = keyringSelection
+
+	return m
+}
+
+// processKeyringSelection fills the address of the selected key
+// into the input, from which the keyring selection was opened.
+func (m Model) processKeyringSelection() (tea.Model, tea.Cmd) {
+	selected, ok := m.list.SelectedItem().(item)
+	if !ok {
+		m.err = fmt.Errorf("failed to cast list item to item; got: %T", m.list.SelectedItem())
+
+		return m, nil
+	}
+
+	inputs := m.actionInputs
+	if m.keyringReturnState == forwardingInput {
+		inputs = m.forwardingInputs
+	}
+
+	inputs[m.focusIndex].SetValue(selected.value)
+	inputs[m.focusIndex].CursorEnd()
+
+	return m.cancelKeyringSelection(), nil
+}
+
+// cancelKeyringSelection returns to the inputs, from which the keyring selection was opened.
+func (m Model) cancelKeyringSelection() Model {
+	m.state = m.keyringReturnState
+
+	return m
+}
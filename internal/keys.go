@@ -21,4 +21,19 @@ const (
 	Down     = "down"
 	Tab      = "tab"
 	ShiftTab = "shift+tab"
+	CtrlR    = "ctrl+r"
+	CtrlE    = "ctrl+e"
+	CtrlL    = "ctrl+l"
+	CtrlZ    = "ctrl+z"
+	CtrlY    = "ctrl+y"
+	CtrlP    = "ctrl+p"
+	CtrlN    = "ctrl+n"
+	CtrlX    = "ctrl+x"
+	CtrlV    = "ctrl+v"
+	CtrlO    = "ctrl+o"
+	CtrlT    = "ctrl+t"
+	CtrlK    = "ctrl+k"
+
+	LeftBracket  = "["
+	RightBracket = "]"
 )
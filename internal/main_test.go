@@ -17,9 +17,16 @@
 
 package internal
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"os"
+	"testing"
 
-var (
-	bold       = lipgloss.NewStyle().Bold(true)
-	errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	"github.com/noble-assets/orbiter/testutil"
 )
+
+func TestMain(m *testing.M) {
+	// NOTE: this is required to validate Noble addresses with the correct bech32 prefix.
+	testutil.SetSDKConfig()
+
+	os.Exit(m.Run())
+}
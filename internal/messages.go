@@ -0,0 +1,200 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// messages contains the user-facing strings of the UI in a single language.
+//
+// NOTE: only the action and forwarding selection screens are localized so far.
+type messages struct {
+	// generatorTitle is the heading of the action selection.
+	generatorTitle string
+	// welcome introduces the tool and contains a placeholder for the emphasized actionTerm.
+	welcome    string
+	actionTerm string
+	// addAnother asks for another action and contains a placeholder for the next step,
+	// which is either nextForwarding or nextConfirmation.
+	addAnother       string
+	nextForwarding   string
+	nextConfirmation string
+	currentActions   string
+	// importedHint and configuredHint contain a placeholder for the preset protocol.
+	importedHint    string
+	configuredHint  string
+	undoHint        string
+	importHint      string
+	actionListTitle string
+	// noMoreActions is the title of the list item to proceed without further actions,
+	// which leads to proceedForwarding or proceedConfirmation.
+	noMoreActions       string
+	proceedForwarding   string
+	proceedConfirmation string
+	// actionDescriptions contains the descriptions of the actions,
+	// falling back to the ones of the action descriptors.
+	actionDescriptions map[core.ActionID]string
+
+	forwardingTitle       string
+	forwardingExplanation string
+	protocolListTitle     string
+	// protocolDescriptions contains the descriptions of the protocols,
+	// falling back to the ones of the forwarding descriptors.
+	protocolDescriptions map[core.ProtocolID]string
+}
+
+// catalogs maps the available languages to the constructors of their messages.
+var catalogs = map[string]func() messages{
+	"en": englishMessages,
+	"de": germanMessages,
+}
+
+// LanguageNames returns the sorted names of the available languages.
+func LanguageNames() []string {
+	names := make([]string, 0, len(catalogs))
+	for name := range catalogs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// ParseLanguage checks that the given language is available.
+// An empty name selects the language of the environment.
+func ParseLanguage(name string) error {
+	if _, found := catalogs[name]; !found && name != "" {
+		return fmt.Errorf(
+			"unknown language %q; expected one of: %s",
+			name,
+			strings.Join(LanguageNames(), ", "),
+		)
+	}
+
+	return nil
+}
+
+// lookupMessages returns the messages of the given language. If no language is given,
+// it is taken from the locale environment variables, falling back to English.
+func lookupMessages(name string) messages {
+	if name == "" {
+		name = environmentLanguage()
+	}
+
+	newMessages, found := catalogs[name]
+	if !found {
+		return englishMessages()
+	}
+
+	return newMessages()
+}
+
+// environmentLanguage returns the language code of the locale configured
+// in the environment (e.g. "de" for "de_DE.UTF-8"), using the POSIX precedence.
+func environmentLanguage() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			language, _, _ := strings.Cut(value, "_")
+			language, _, _ = strings.Cut(language, ".")
+
+			return strings.ToLower(language)
+		}
+	}
+
+	return ""
+}
+
+// englishMessages returns the default English messages.
+func englishMessages() messages {
+	return messages{
+		generatorTitle: "Orbiter Payload Generator",
+		welcome: "Welcome! This tool helps you build payloads for cross-chain operations.\n" +
+			"To start, select if you want to add a so-called %s to the payload.\n\n" +
+			"Actions are optional operations that run before forwarding (e.g. fee payments).\n" +
+			"The selected actions will be run sequentially, so bear that in mind.\n\n",
+		actionTerm:          "action",
+		addAnother:          "Add another action or continue to the %s.\n",
+		nextForwarding:      "forwarding selection",
+		nextConfirmation:    "payload confirmation",
+		currentActions:      "Current actions:\n",
+		importedHint:        "Imported payload with %s forwarding, which is kept as is",
+		configuredHint:      "Configured %s forwarding; the actions run before it",
+		undoHint:            "Ctrl+Z to undo, Ctrl+Y to redo changes to the actions",
+		importHint:          "Ctrl+O to import an existing payload",
+		actionListTitle:     "Select an action to add:",
+		noMoreActions:       "No more actions",
+		proceedForwarding:   "Proceed to forwarding selection",
+		proceedConfirmation: "Proceed to the payload confirmation",
+
+		forwardingTitle: "Select Forwarding Protocol",
+		forwardingExplanation: "Now choose how to forward your transaction " +
+			"to the destination chain.\n" +
+			"Each protocol supports different chains and tokens:\n\n",
+		protocolListTitle: "Select a protocol:",
+	}
+}
+
+// germanMessages returns the German messages.
+func germanMessages() messages {
+	return messages{
+		generatorTitle: "Orbiter-Payload-Generator",
+		welcome: "Willkommen! Dieses Tool hilft dir, " +
+			"Payloads für Cross-Chain-Operationen zu erstellen.\n" +
+			"Wähle zunächst, ob du dem Payload eine sogenannte %s hinzufügen möchtest.\n\n" +
+			"Aktionen sind optionale Operationen, die vor der Weiterleitung ausgeführt werden " +
+			"(z. B. Gebührenzahlungen).\n" +
+			"Die gewählten Aktionen werden nacheinander ausgeführt, bitte beachte das.\n\n",
+		actionTerm:       "Aktion",
+		addAnother:       "Füge eine weitere Aktion hinzu oder fahre mit der %s fort.\n",
+		nextForwarding:   "Auswahl der Weiterleitung",
+		nextConfirmation: "Bestätigung des Payloads",
+		currentActions:   "Aktuelle Aktionen:\n",
+		importedHint:     "Importierter Payload mit %s-Weiterleitung, die unverändert bleibt",
+		configuredHint:   "Konfigurierte %s-Weiterleitung; die Aktionen laufen davor",
+		undoHint: "Strg+Z macht Änderungen an den Aktionen rückgängig, " +
+			"Strg+Y stellt sie wieder her",
+		importHint:          "Strg+O importiert einen vorhandenen Payload",
+		actionListTitle:     "Wähle eine Aktion aus:",
+		noMoreActions:       "Keine weiteren Aktionen",
+		proceedForwarding:   "Weiter zur Auswahl der Weiterleitung",
+		proceedConfirmation: "Weiter zur Bestätigung des Payloads",
+		actionDescriptions: map[core.ActionID]string{
+			core.ACTION_FEE:  "Gebührenzahlung hinzufügen",
+			core.ACTION_SWAP: "Token-Tausch hinzufügen",
+		},
+
+		forwardingTitle: "Weiterleitungsprotokoll auswählen",
+		forwardingExplanation: "Wähle nun, wie die Transaktion " +
+			"an die Ziel-Chain weitergeleitet wird.\n" +
+			"Jedes Protokoll unterstützt andere Chains und Tokens:\n\n",
+		protocolListTitle: "Wähle ein Protokoll aus:",
+		protocolDescriptions: map[core.ProtocolID]string{
+			core.PROTOCOL_CCTP:      "Circles Cross-Chain Transfer Protocol (USDC-Transfers)",
+			core.PROTOCOL_IBC:       "Inter-Blockchain Communication (Cosmos-Ökosystem)",
+			core.PROTOCOL_HYPERLANE: "Hyperlane-Interchain-Protokoll",
+			core.PROTOCOL_INTERNAL:  "Interner Transfer auf Noble",
+		},
+	}
+}
@@ -0,0 +1,315 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+// paletteWidth is the width of the command palette, if the window is wide enough.
+const paletteWidth = 60
+
+// paletteHeight is the height of the command palette, which fits all commands.
+const paletteHeight = 20
+
+// paletteCommand is a command, that can be run from the command palette on any screen.
+type paletteCommand struct {
+	// name identifies the command in the palette list.
+	name        string
+	title, desc string
+	// implemented marks commands, that are fully supported by the generator.
+	// Other commands are only listed in experimental mode.
+	implemented bool
+	run         func(m Model) (tea.Model, tea.Cmd)
+}
+
+// copyResultMsg is sent when copying the payload to the clipboard finished.
+type copyResultMsg struct {
+	err error
+}
+
+// paletteCommands returns the commands of the command palette, in the listed order.
+//
+// NOTE: this is a function rather than a package variable,
+// because the commands reference methods which use the palette themselves.
+func paletteCommands() []paletteCommand {
+	return []paletteCommand{
+		{
+			name:        "add-fee",
+			title:       "Add fee action",
+			desc:        "Configure a fee payment action",
+			implemented: true,
+			run: func(m Model) (tea.Model, tea.Cmd) {
+				return m.paletteAddAction(core.ACTION_FEE)
+			},
+		},
+		{
+			name:  "add-swap",
+			title: "Add swap action",
+			desc:  "Configure a token swap action",
+			run: func(m Model) (tea.Model, tea.Cmd) {
+				return m.paletteAddAction(core.ACTION_SWAP)
+			},
+		},
+		{
+			name:        "choose-forwarding",
+			title:       "Choose forwarding",
+			desc:        "Select and configure the forwarding protocol",
+			implemented: true,
+			run:         Model.paletteChooseForwarding,
+		},
+		{
+			name:        "change-format",
+			title:       "Change output format",
+			desc:        "Select the format, in which the payload is printed",
+			implemented: true,
+			run:         Model.paletteChangeFormat,
+		},
+		{
+			name:        "copy-payload",
+			title:       "Copy payload",
+			desc:        "Copy the encoded payload to the clipboard",
+			implemented: true,
+			run:         Model.paletteCopyPayload,
+		},
+		{
+			name:        "start-over",
+			title:       "Start over",
+			desc:        "Discard all actions and the forwarding",
+			implemented: true,
+			run: func(m Model) (tea.Model, tea.Cmd) {
+				return m.confirmStartOver(), nil
+			},
+		},
+	}
+}
+
+// openPalette shows the command palette on top of the current screen.
+func (m Model) openPalette() Model {
+	commands := paletteCommands()
+	items := make([]item, 0, len(commands))
+	for _, c := range commands {
+		items = append(items, item{
+			title:       c.title,
+			desc:        c.desc,
+			value:       c.name,
+			implemented: c.implemented,
+		})
+	}
+
+	l := list.New(m.listItems(items...), list.NewDefaultDelegate(), 0, 0)
+	l.Title = "Commands"
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(false)
+
+	l = m.resizePalette(l)
+	m.palette = &l
+
+	return m
+}
+
+// resizePalette fits the command palette into the stored window dimensions.
+func (m Model) resizePalette(l list.Model) list.Model {
+	width, height := paletteWidth, paletteHeight
+	if m.windowWidth > 0 {
+		// NOTE: the border and padding of the dialog take four columns.
+		width = min(width, max(m.windowWidth-4, 0))
+	}
+	if m.windowHeight > 0 {
+		height = min(height, max(m.windowHeight-2, 0))
+	}
+
+	l.SetSize(width, height)
+
+	return l
+}
+
+// writePalette renders the open command palette below the current screen.
+func (m Model) writePalette(s *strings.Builder) {
+	if m.palette == nil {
+		return
+	}
+
+	s.WriteString("\n\n")
+	hint := m.styles.hint.Render("Type to search, Enter to run, Esc to close")
+	s.WriteString(m.styles.dialog.Render(m.palette.View() + "\n" + hint))
+}
+
+// updatePalette handles the key presses while the command palette is open.
+// Enter runs the selected command, while typed characters search the commands.
+func (m Model) updatePalette(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			m.palette = nil
+
+			return m, nil
+		case "enter":
+			return m.runPaletteCommand()
+		case Up, Down:
+			// NOTE: the search is applied before moving, because the list
+			// neither navigates nor highlights the selection while searching.
+			if m.palette.FilterState() == list.Filtering && m.palette.FilterValue() != "" {
+				l := *m.palette
+				l.SetFilterState(list.FilterApplied)
+				m.palette = &l
+			}
+		}
+
+		// NOTE: typing starts or continues the search, without pressing "/" first.
+		if key.Type == tea.KeyRunes && m.palette.FilterState() != list.Filtering {
+			l, _ := m.palette.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+			m.palette = &l
+		}
+	}
+
+	l, cmd := m.palette.Update(msg)
+	m.palette = &l
+
+	return m, cmd
+}
+
+// runPaletteCommand closes the palette and runs the selected command.
+func (m Model) runPaletteCommand() (tea.Model, tea.Cmd) {
+	selected, ok := m.palette.SelectedItem().(item)
+	if !ok {
+		// No command matches the search
+		return m, nil
+	}
+
+	m.palette = nil
+	for _, c := range paletteCommands() {
+		if c.name == selected.value {
+			return c.run(m)
+		}
+	}
+
+	return m, nil
+}
+
+// paletteAddAction shows the inputs of the given action. A forwarding configured before
+// is kept and confirmed again, once no more actions are added.
+func (m Model) paletteAddAction(id core.ActionID) (tea.Model, tea.Cmd) {
+	if m.state == expertInput && id == core.ACTION_FEE {
+		return m.addExpertFee()
+	}
+
+	updated, cmd := m.selectAction(id.String())
+	next, ok := updated.(Model)
+	if !ok || next.state != actionInput {
+		return updated, cmd
+	}
+
+	if next.forwarding != nil {
+		next.presetForwarding = next.forwarding
+		next.forwarding = nil
+	}
+
+	return next, cmd
+}
+
+// paletteChooseForwarding lists the forwarding protocols to configure the forwarding anew,
+// or switches the protocol in the expert mode.
+func (m Model) paletteChooseForwarding() (tea.Model, tea.Cmd) {
+	if m.opts.Imported != nil {
+		m.err = errors.New("the imported forwarding cannot be edited")
+
+		return m, nil
+	}
+
+	if m.state == expertInput {
+		return m.switchExpertProtocol(), nil
+	}
+
+	m.forwarding = nil
+
+	return m.initForwardingSelection(), nil
+}
+
+// paletteChangeFormat lists the output formats, once the forwarding was configured.
+func (m Model) paletteChangeFormat() (tea.Model, tea.Cmd) {
+	switch {
+	case !m.opts.SelectFormat:
+		m.err = errors.New("the output format is fixed by the command line flags")
+	case m.forwarding == nil:
+		m.err = errors.New("configure the forwarding before selecting the output format")
+	default:
+		return m.initFormatSelection(), nil
+	}
+
+	return m, nil
+}
+
+// paletteCopyPayload copies the payload built from the current contents to the clipboard.
+func (m Model) paletteCopyPayload() (tea.Model, tea.Cmd) {
+	fwd := m.forwarding
+	if fwd == nil {
+		fwd = m.presetForwarding
+	}
+
+	payload, err := builder.BuildPayload(fwd, m.actions)
+	if err != nil {
+		m.err = fmt.Errorf("failed to build payload: %w", err)
+
+		return m, nil
+	}
+
+	return m, func() tea.Msg {
+		if err := clipboard.WriteAll(payload); err != nil {
+			return copyResultMsg{err: fmt.Errorf("failed to copy to clipboard: %w", err)}
+		}
+
+		return copyResultMsg{}
+	}
+}
+
+// confirmStartOver asks the user to confirm discarding all contents,
+// before returning to the first screen.
+func (m Model) confirmStartOver() Model {
+	return m.openConfirm(
+		"Discard all actions and the forwarding and start over?",
+		func(m Model, confirmed bool) (tea.Model, tea.Cmd) {
+			if !confirmed {
+				return m, nil
+			}
+
+			opts := m.opts
+			opts.Imported = nil
+			opts.Review = false
+
+			fresh := InitialModel(opts)
+			fresh.idleTag = m.idleTag
+			fresh.windowWidth = m.windowWidth
+			fresh.windowHeight = m.windowHeight
+			fresh.list = fresh.resizeList(fresh.list)
+
+			return fresh, textinput.Blink
+		},
+	)
+}
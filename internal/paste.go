@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pasteErrorMsg is sent when reading the clipboard failed.
+type pasteErrorMsg struct {
+	err error
+}
+
+// pasteFromClipboard reads the clipboard and returns its content as paste event,
+// so that it is sanitized like the pastes of the terminal.
+func pasteFromClipboard() tea.Msg {
+	value, err := clipboard.ReadAll()
+	if err != nil {
+		return pasteErrorMsg{err: fmt.Errorf("failed to paste from clipboard: %w", err)}
+	}
+
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(value), Paste: true}
+}
+
+// sanitizePaste removes surrounding whitespace and control characters like newlines
+// from pasted values. Otherwise, the inputs would keep them as spaces, which count
+// towards the character limit and can truncate a pasted address.
+func sanitizePaste(msg tea.Msg) tea.Msg {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || !key.Paste {
+		return msg
+	}
+
+	value := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, strings.TrimSpace(string(key.Runes)))
+	key.Runes = []rune(value)
+
+	return key
+}
@@ -0,0 +1,206 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+// errNoAnswer is returned when no more answers can be read.
+var errNoAnswer = errors.New("no answer")
+
+// prompter asks for the payload contents line by line. It is the fallback
+// for terminals, that the TUI cannot run in.
+type prompter struct {
+	opts    builder.Options
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+// RunPrompts asks for the payload contents with plain line-based prompts written to out
+// and answered through in, and returns the generated payload along with the notes
+// of its actions. Invalid answers are reported and asked for again.
+// An error is only returned if reading the answers fails, e.g. at the end of the input.
+func RunPrompts(opts builder.Options, in io.Reader, out io.Writer) (string, []string, error) {
+	p := prompter{opts: opts, scanner: bufio.NewScanner(in), out: out}
+
+	var (
+		actions []*core.Action
+		notes   []string
+	)
+	for {
+		add, err := p.ask("Add a fee action? [y/N]")
+		if err != nil {
+			return "", nil, err
+		}
+
+		if !strings.EqualFold(add, "y") {
+			break
+		}
+
+		act, note, err := p.askFeeAction()
+		if err != nil {
+			return "", nil, err
+		}
+
+		actions = append(actions, act)
+		notes = append(notes, note)
+	}
+
+	for {
+		fwd, err := p.askForwarding()
+		if err != nil {
+			return "", nil, err
+		}
+
+		payload, err := builder.BuildPayload(fwd, actions)
+		if err != nil {
+			fmt.Fprintf(p.out, "Error: %v\n", err)
+
+			continue
+		}
+
+		return payload, notes, nil
+	}
+}
+
+// ask writes the question and returns the trimmed answer.
+func (p prompter) ask(question string) (string, error) {
+	fmt.Fprint(p.out, question+": ")
+
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", fmt.Errorf("%w: failed to read answer: %w", errNoAnswer, err)
+		}
+
+		return "", fmt.Errorf("%w: input ended before all questions were answered", errNoAnswer)
+	}
+
+	return strings.TrimSpace(p.scanner.Text()), nil
+}
+
+// askFeeAction asks for the fee action attributes until they are valid.
+func (p prompter) askFeeAction() (*core.Action, string, error) {
+	for {
+		recipient, err := p.ask("Fee recipient address")
+		if err != nil {
+			return nil, "", err
+		}
+
+		bps, err := p.ask("Basis points (e.g. 100 or 1% for 1%)")
+		if err != nil {
+			return nil, "", err
+		}
+
+		note, err := p.ask("Note (optional; only included in the JSON output)")
+		if err != nil {
+			return nil, "", err
+		}
+
+		basisPoints, err := builder.ParseBasisPoints(p.opts, bps)
+		if err == nil {
+			var act *core.Action
+			if act, err = builder.NewFeeAction(recipient, basisPoints); err == nil {
+				return act, note, nil
+			}
+		}
+
+		fmt.Fprintf(p.out, "Error: %v\n", err)
+	}
+}
+
+// askForwarding asks for the protocol and its attributes until they are valid.
+func (p prompter) askForwarding() (*core.Forwarding, error) {
+	for {
+		protocol, err := p.ask("Forwarding protocol (cctp or internal)")
+		if err != nil {
+			return nil, err
+		}
+
+		var fwd *core.Forwarding
+		switch strings.ToLower(protocol) {
+		case "cctp":
+			fwd, err = p.askCCTPForwarding()
+		case "internal":
+			var recipient string
+			if recipient, err = p.ask("Recipient address (bech32 Noble address)"); err == nil {
+				fwd, err = builder.NewInternalForwarding(recipient)
+			}
+		default:
+			err = fmt.Errorf("unknown protocol %q", protocol)
+		}
+
+		switch {
+		case err == nil:
+			return fwd, nil
+		case errors.Is(err, errNoAnswer):
+			return nil, err
+		default:
+			fmt.Fprintf(p.out, "Error: %v\n", err)
+		}
+	}
+}
+
+// askCCTPForwarding asks for the attributes of a CCTP forwarding. The destination caller
+// defaults to the one of the domain, which is shown with the question.
+func (p prompter) askCCTPForwarding() (*core.Forwarding, error) {
+	domainStr, err := p.ask("Destination domain (e.g. 0)")
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := builder.ParseDomain(domainStr)
+	if err != nil {
+		return nil, err
+	}
+
+	mintRecipient, err := p.ask("Mint recipient (hex with '0x' prefix, bech32 or base64)")
+	if err != nil {
+		return nil, err
+	}
+
+	question := "Destination caller (optional)"
+	defaultCaller := builder.DefaultDestinationCaller(p.opts, domain)
+	if defaultCaller != "" {
+		question = fmt.Sprintf("Destination caller (default %s)", defaultCaller)
+	}
+
+	destCaller, err := p.ask(question)
+	if err != nil {
+		return nil, err
+	}
+
+	if destCaller == "" {
+		destCaller = defaultCaller
+	}
+
+	passthrough, err := p.ask("Passthrough payload (optional; prefix a file path with '@')")
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.NewCCTPForwarding(p.opts, domain, mintRecipient, destCaller, passthrough)
+}
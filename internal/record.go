@@ -0,0 +1,97 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ansiSequenceRegex matches the escape sequences used for styling the views,
+// which are removed from the transcript to keep it readable as plain text.
+var ansiSequenceRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// Recorder wraps the model to write a transcript of the screens shown in the TUI.
+// Each screen is written as it was rendered when leaving it, so that the transcript
+// contains the entered values, followed by the final screen once the program quits.
+type Recorder struct {
+	model Model
+	w     io.Writer
+	// view is the last rendered view of the current screen.
+	view  string
+	steps int
+	err   error
+}
+
+// NewRecorder returns the recorder of the given model, writing the transcript to w.
+func NewRecorder(m Model, w io.Writer) *Recorder {
+	return &Recorder{model: m, w: w, view: m.View()}
+}
+
+func (r *Recorder) Init() tea.Cmd {
+	return r.model.Init()
+}
+
+func (r *Recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	previous := r.model.state
+
+	updated, cmd := r.model.Update(msg)
+	if m, ok := updated.(Model); ok {
+		r.model = m
+	}
+
+	if r.model.state != previous {
+		r.writeStep()
+	}
+
+	r.view = r.model.View()
+
+	return r, cmd
+}
+
+func (r *Recorder) View() string {
+	return r.model.View()
+}
+
+// Finish writes the final screen and returns the wrapped model,
+// along with the first error that occurred while writing the transcript.
+func (r *Recorder) Finish() (Model, error) {
+	r.writeStep()
+
+	return r.model, r.err
+}
+
+// writeStep writes the last rendered view of the current screen to the transcript.
+func (r *Recorder) writeStep() {
+	if r.err != nil {
+		return
+	}
+
+	// NOTE: the views are padded to the window width, which is not kept in the transcript.
+	lines := strings.Split(ansiSequenceRegex.ReplaceAllString(r.view, ""), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	r.steps++
+	_, r.err = fmt.Fprintf(r.w, "--- Screen %d ---\n%s\n\n", r.steps, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,196 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+// noMoreActions is the value of the list item to proceed to the forwarding selection,
+// which identifies it independently of its localized title.
+const noMoreActions = "no-more-actions"
+
+// actionDescriptor registers an action with the UI.
+//
+// The inputs of an implemented action are set up by init, rendered by write
+// and turned into the action by process when confirming them.
+type actionDescriptor struct {
+	id          core.ActionID
+	desc        string
+	implemented bool
+
+	init    func(Model) Model
+	write   func(Model, *strings.Builder)
+	process func(Model) (tea.Model, tea.Cmd)
+	// example returns an example value for the input at the given index.
+	example func(index int) string
+	// addressInputs contains the indices of the inputs accepting a Noble address,
+	// which can be selected from the keyring.
+	addressInputs []int
+}
+
+// actionDescriptors returns all actions that can be selected in the UI,
+// in the order they are listed.
+//
+// NOTE: this is a function rather than a package variable,
+// because the descriptors reference methods which use the registry themselves.
+func actionDescriptors() []actionDescriptor {
+	return []actionDescriptor{
+		{
+			id:            core.ACTION_FEE,
+			desc:          "Add fee payment action",
+			implemented:   true,
+			init:          Model.initFeeActionInput,
+			write:         Model.writeFeeActionSelection,
+			process:       Model.processFeeAction,
+			example:       feeActionExample,
+			addressInputs: []int{0},
+		},
+		{
+			id:   core.ACTION_SWAP,
+			desc: "Add token swap action",
+		},
+	}
+}
+
+// lookupActionDescriptor returns the registered descriptor of the given action.
+func lookupActionDescriptor(id core.ActionID) (actionDescriptor, bool) {
+	for _, d := range actionDescriptors() {
+		if d.id == id {
+			return d, true
+		}
+	}
+
+	return actionDescriptor{}, false
+}
+
+func feeActionExample(index int) string {
+	switch index {
+	case 0:
+		return testutil.NewNobleAddress()
+	case 1:
+		return "100"
+	case 2:
+		return "relayer cut"
+	default:
+		return ""
+	}
+}
+
+// forwardingDescriptor registers a forwarding protocol with the UI.
+//
+// The inputs of an implemented protocol are set up by init, rendered by write
+// and turned into the forwarding by process when confirming them.
+// The forwarding is built from the inputs by build, which is used by process.
+type forwardingDescriptor struct {
+	id          core.ProtocolID
+	desc        string
+	implemented bool
+
+	init    func(Model) Model
+	write   func(Model, *strings.Builder)
+	process func(Model) (tea.Model, tea.Cmd)
+	build   func(Model) (*core.Forwarding, error)
+	// fields contains the names of the builder fields for each input, in order,
+	// so that errors can be associated with the offending input.
+	fields []string
+	// example returns an example value for the input at the given index.
+	example func(index int) string
+	// addressInputs contains the indices of the inputs accepting a Noble address,
+	// which can be selected from the keyring.
+	addressInputs []int
+	// deferred contains the expensive validations of the inputs,
+	// which are only run once the user paused typing.
+	deferred []deferredValidation
+}
+
+// forwardingDescriptors returns all forwarding protocols that can be selected in the UI,
+// in the order they are listed.
+func forwardingDescriptors() []forwardingDescriptor {
+	return []forwardingDescriptor{
+		{
+			id:          core.PROTOCOL_CCTP,
+			desc:        "Circle's Cross-Chain Transfer Protocol (USDC transfers)",
+			implemented: true,
+			init:        Model.initCCTPForwardingInput,
+			write:       Model.writeCCTPForwardingSelection,
+			process:     Model.processCCTPForwarding,
+			build:       Model.buildCCTPForwarding,
+			fields:      cctpFields,
+			example:     cctpForwardingExample,
+			deferred:    []deferredValidation{ensValidation},
+		},
+		{
+			id:   core.PROTOCOL_IBC,
+			desc: "Inter-Blockchain Communication (Cosmos ecosystem)",
+		},
+		{
+			id:   core.PROTOCOL_HYPERLANE,
+			desc: "Hyperlane interchain protocol",
+		},
+		{
+			id:            core.PROTOCOL_INTERNAL,
+			desc:          "Internal transfer on Noble",
+			implemented:   true,
+			init:          Model.initInternalForwardingInput,
+			write:         Model.writeInternalForwardingSelection,
+			process:       Model.processInternalForwarding,
+			build:         Model.buildInternalForwarding,
+			fields:        internalFields,
+			example:       internalForwardingExample,
+			addressInputs: []int{0},
+		},
+	}
+}
+
+// lookupForwardingDescriptor returns the registered descriptor of the given protocol.
+func lookupForwardingDescriptor(id core.ProtocolID) (forwardingDescriptor, bool) {
+	for _, d := range forwardingDescriptors() {
+		if d.id == id {
+			return d, true
+		}
+	}
+
+	return forwardingDescriptor{}, false
+}
+
+func cctpForwardingExample(index int) string {
+	switch index {
+	case 0:
+		return "0"
+	case 1, 2:
+		return hexutil.Encode(testutil.RandomBytes(20))
+	case 3:
+		return "hello from orbiter"
+	default:
+		return ""
+	}
+}
+
+func internalForwardingExample(index int) string {
+	if index == 0 {
+		return testutil.NewNobleAddress()
+	}
+
+	return ""
+}
@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// theme contains all named styles, that are used to render the UI.
+type theme struct {
+	// title is used for the headings of each screen.
+	title lipgloss.Style
+	// emphasis highlights terms within explanatory text.
+	emphasis lipgloss.Style
+	// error is used for error messages.
+	error lipgloss.Style
+	// hint is used for secondary information like key bindings.
+	hint lipgloss.Style
+	// dialog frames dialogs shown on top of the current screen.
+	dialog lipgloss.Style
+}
+
+// themes maps the names of the available themes to their constructors.
+var themes = map[string]func() theme{
+	"default":       defaultTheme,
+	"no-color":      noColorTheme,
+	"high-contrast": highContrastTheme,
+}
+
+// ThemeNames returns the sorted names of the available themes.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// ParseTheme checks that a theme with the given name exists.
+// An empty name selects the default theme.
+func ParseTheme(name string) error {
+	if _, found := themes[name]; !found && name != "" {
+		return fmt.Errorf(
+			"unknown theme %q; expected one of: %s",
+			name,
+			strings.Join(ThemeNames(), ", "),
+		)
+	}
+
+	return nil
+}
+
+// lookupTheme returns the theme with the given name. If no name is given,
+// the no-color theme is used when the NO_COLOR environment variable is set.
+func lookupTheme(name string) theme {
+	if name == "" && os.Getenv("NO_COLOR") != "" {
+		name = "no-color"
+	}
+
+	newTheme, found := themes[name]
+	if !found {
+		return defaultTheme()
+	}
+
+	return newTheme()
+}
+
+// defaultTheme returns the default colored theme.
+func defaultTheme() theme {
+	return theme{
+		title:    lipgloss.NewStyle().Bold(true),
+		emphasis: lipgloss.NewStyle().Bold(true),
+		error:    lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		hint:     lipgloss.NewStyle().Faint(true),
+		dialog: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("62")).
+			Padding(0, 1),
+	}
+}
+
+// noColorTheme returns a theme, that only uses text attributes and no colors.
+func noColorTheme() theme {
+	return theme{
+		title:    lipgloss.NewStyle().Bold(true),
+		emphasis: lipgloss.NewStyle().Bold(true),
+		error:    lipgloss.NewStyle().Bold(true),
+		hint:     lipgloss.NewStyle(),
+		dialog:   lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1),
+	}
+}
+
+// highContrastTheme returns a theme, that avoids faint text and uses bright colors.
+func highContrastTheme() theme {
+	return theme{
+		title:    lipgloss.NewStyle().Bold(true).Underline(true),
+		emphasis: lipgloss.NewStyle().Bold(true),
+		error:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9")),
+		hint:     lipgloss.NewStyle().Foreground(lipgloss.Color("15")),
+		dialog: lipgloss.NewStyle().
+			Border(lipgloss.ThickBorder()).
+			BorderForeground(lipgloss.Color("15")).
+			Padding(0, 1),
+	}
+}
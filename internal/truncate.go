@@ -0,0 +1,89 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/noble-assets/orbiter/types/controller/action"
+	"github.com/noble-assets/orbiter/types/core"
+)
+
+const (
+	// ellipsis replaces the middle of truncated addresses.
+	ellipsis = "…"
+	// minTruncatedWidth is the minimum width of truncated addresses,
+	// so that their start and end remain recognizable on very narrow terminals.
+	minTruncatedWidth = 16
+)
+
+// truncateMiddle shortens the given value to the given width by replacing its middle
+// with an ellipsis, keeping the prefix (e.g. "noble1") and the last characters readable.
+// Values that fit into the width are returned unchanged.
+func truncateMiddle(value string, width int) string {
+	runes := []rune(value)
+	if width <= 0 || len(runes) <= width {
+		return value
+	}
+
+	// NOTE: the ellipsis takes a single column.
+	keep := width - 1
+	head := (keep + 1) / 2
+
+	return string(runes[:head]) + ellipsis + string(runes[len(runes)-(keep-head):])
+}
+
+// truncateAddress shortens the given address to fit into the terminal width,
+// minus the given number of columns, that are taken by the surrounding text.
+// Addresses are not truncated before the window size is known.
+//
+// NOTE: only lists and summaries truncate addresses, while the confirmation
+// screen always shows the full values.
+func (m Model) truncateAddress(address string, reserved int) string {
+	if m.windowWidth <= 0 {
+		return address
+	}
+
+	return truncateMiddle(address, max(m.windowWidth-reserved, minTruncatedWidth))
+}
+
+// summarizeAction returns a single line summary of the action for the action selection,
+// with the contained addresses truncated to the given width each.
+func (m Model) summarizeAction(act *core.Action, reserved int) string {
+	attr, err := act.CachedAttributes()
+	if err != nil {
+		return act.Id.String()
+	}
+
+	fee, ok := attr.(*action.FeeAttributes)
+	if !ok {
+		return act.Id.String()
+	}
+
+	fees := make([]string, 0, len(fee.FeesInfo))
+	for _, info := range fee.FeesInfo {
+		fees = append(fees, fmt.Sprintf(
+			"%s to %s",
+			formatBasisPoints(info.BasisPoints),
+			m.truncateAddress(info.Recipient, reserved),
+		))
+	}
+
+	return fmt.Sprintf("%s (%s)", act.Id.String(), strings.Join(fees, ", "))
+}
@@ -18,13 +18,18 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/noble-assets/orbiter/types/core"
+
+	"github.com/noble-assets/orbgen/internal/builder"
 )
 
 // state is a toggle for the currently selected UI state.
@@ -32,35 +37,122 @@ type state int
 
 const (
 	actionSelection state = iota
-	feeActionInput
+	actionInput
 	forwardingSelection
-	cctpForwardingInput
-	internalForwardingInput
+	forwardingInput
+	payloadConfirmation
+	keyringSelection
+	formatSelection
+	expertInput
+	payloadImport
 )
 
+// errUnhandledState returns the error shown for a state that is not wired into the model.
+func errUnhandledState(s state) error {
+	return fmt.Errorf("unhandled state: %v", s)
+}
+
+// listHeightOffset is the number of lines reserved above the selection lists.
+const listHeightOffset = 8
+
+// listItemReserved is the number of columns taken by the padding of list items.
+const listItemReserved = 4
+
 type item struct {
 	title, desc string
+	// value is the full value represented by the item, if it is shortened
+	// in the description (e.g. a truncated address), or identifies items
+	// with a localized title.
+	value string
+	// implemented marks items that are fully supported by the generator.
+	// Other items are only listed in experimental mode.
+	implemented bool
 }
 
 func (i item) Title() string       { return i.title }
 func (i item) Description() string { return i.desc }
 func (i item) FilterValue() string { return i.title }
 
-var focusIndex int
+// fieldError annotates an error with the input field it most likely pertains to.
+type fieldError struct {
+	field string
+	err   error
+}
+
+func (e fieldError) Error() string { return e.field + ": " + e.err.Error() }
+func (e fieldError) Unwrap() error { return e.err }
 
 // Model contains all relevant information and state
 // for the UI to interactively build an Orbiter payload.
 type Model struct {
-	state state
-	list  list.Model
+	opts     builder.Options
+	styles   theme
+	messages messages
+	state    state
+	list     list.Model
 
-	actionInputs     []textinput.Model
+	// selectedAction is the action that is currently configured in the action inputs.
+	selectedAction core.ActionID
+	actionInputs   []textinput.Model
+	// selectedProtocol is the protocol that is currently configured in the forwarding inputs.
+	selectedProtocol core.ProtocolID
 	forwardingInputs []textinput.Model
+	// focusIndex is the index of the currently focused input.
+	focusIndex int
+	// idleTag identifies the latest idle timer, which is restarted on every key press.
+	idleTag int
+	// keyringReturnState is the input state, from which the keyring selection was opened.
+	keyringReturnState state
+	// randomValues contains the last randomly generated value
+	// for each forwarding input, to enable rerolling them.
+	randomValues []string
+	// autoFilledCaller is the destination caller, that was prefilled for the domain
+	// entered in prefilledDomain, to tell it apart from callers entered by the user.
+	autoFilledCaller string
+	prefilledDomain  string
+	// validations contains the results of the deferred validations by input index.
+	validations map[int]validationResult
+	// validationTag identifies the latest validation timer,
+	// which is restarted whenever a validated input changes.
+	validationTag int
 
-	actions    []*core.Action
-	forwarding *core.Forwarding
-	err        error
-	payload    string
+	actions []*core.Action
+	history actionHistory
+	// actionNotes contains the optional notes of the actions for the JSON output.
+	actionNotes map[*core.Action]string
+	forwarding  *core.Forwarding
+	// presetForwarding is the forwarding of an imported payload, or the forwarding configured
+	// before the actions, which is confirmed after the action selection.
+	presetForwarding *core.Forwarding
+	err              error
+	payload          string
+	// reviewPayload is the encoded payload shown on the confirmation screen, or the error
+	// building it, which are computed once when entering it instead of on every render.
+	reviewPayload    string
+	reviewPayloadErr error
+	// format is the output format selected in the TUI.
+	format builder.Format
+	// showRawPayload toggles the confirmation screen to show the encoded payload
+	// instead of the human-readable summary.
+	showRawPayload bool
+	// showHexDump toggles the confirmation screen to show a hex dump
+	// of the encoded payload bytes in the scrollable hexDump viewport.
+	showHexDump bool
+	hexDump     viewport.Model
+	// reviewFocus is the section of the confirmation screen, that is edited on Enter.
+	reviewFocus reviewSection
+	// confirm is the dialog, that is shown on top of the current screen, if any.
+	confirm *confirmDialog
+	// expertErrors contains the validation error of each input in the expert mode,
+	// counting the forwarding inputs before the fee action inputs.
+	expertErrors []error
+	// importInput is the input to paste a previously generated payload into.
+	importInput textinput.Model
+	// palette is the command palette, that is shown on top of the current screen, if open.
+	palette *list.Model
+	// status is a transient message, like a confirmation of copying the payload,
+	// that is shown until the next key press.
+	status string
 
 	windowWidth  int
 	windowHeight int
@@ -68,89 +160,411 @@ type Model struct {
 
 // InitialModel creates the default view for the payload generator,
 // that is shown when starting the tool.
-func InitialModel() Model {
-	actionItems := []list.Item{
-		item{title: core.ACTION_FEE.String(), desc: "Add fee payment action"},
-		item{title: core.ACTION_SWAP.String(), desc: "Add token swap action"},
-		item{title: "No more actions", desc: "Proceed to forwarding selection"},
+func InitialModel(opts builder.Options) Model {
+	m := Model{
+		opts:     opts,
+		styles:   lookupTheme(opts.Theme),
+		messages: lookupMessages(opts.Language),
+		actions:  []*core.Action{},
 	}
 
-	l := list.New(actionItems, list.NewDefaultDelegate(), 0, 0)
-	l.Title = "Select an action to add:" //nolint:goconst
+	if opts.Imported != nil {
+		m.actions = slices.Clone(opts.Imported.Orbiter.PreActions)
+		m.presetForwarding = opts.Imported.Orbiter.Forwarding
 
-	return Model{
-		state:   actionSelection,
-		list:    l,
-		actions: []*core.Action{},
+		if opts.Review {
+			return m.initConfirmation(m.presetForwarding)
+		}
 	}
+
+	if opts.Expert {
+		return m.initExpertInput()
+	}
+
+	if opts.ForwardingFirst {
+		return m.initForwardingSelection()
+	}
+
+	return m.initActionSelection()
 }
 
 func (m Model) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, m.idleTimer())
 }
 
 func (m Model) GetPayload() string {
 	return m.payload
 }
 
-// Update handles the different TUI states through the different
-// selection modals.
+// GetActionNotes returns the notes of the actions in the payload by action index,
+// where actions without a note have an empty note.
+func (m Model) GetActionNotes() []string {
+	notes := make([]string, 0, len(m.actions))
+	for _, act := range m.actions {
+		notes = append(notes, m.actionNotes[act])
+	}
+
+	return notes
+}
+
+// GetProgress returns a summary of the actions and forwarding configured so far,
+// so that it can be printed if the program is quit before building the payload.
+func (m Model) GetProgress() []string {
+	// NOTE: the summary is printed outside of the TUI, so addresses are not truncated.
+	m.windowWidth = 0
+
+	progress := make([]string, 0, len(m.actions)+1)
+	for i, act := range m.actions {
+		progress = append(progress, fmt.Sprintf("Action %d: %s", i+1, m.summarizeAction(act, 0)))
+	}
+
+	fwd := m.forwarding
+	if fwd == nil {
+		fwd = m.presetForwarding
+	}
+
+	switch {
+	case fwd != nil:
+		progress = append(progress, "Forwarding: "+fwd.ProtocolId.String())
+		for _, line := range describeForwarding(m.opts, fwd) {
+			progress = append(progress, "  "+line)
+		}
+	case m.state == forwardingInput || m.state == expertInput:
+		progress = append(progress, "Forwarding: "+m.selectedProtocol.String()+" (not submitted)")
+	}
+
+	return progress
+}
+
+// GetFormat returns the output format selected in the TUI,
+// or an empty format if no format was selected.
+func (m Model) GetFormat() builder.Format {
+	return m.format
+}
+
+// Update handles the incoming messages and restarts the idle timer on key presses.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case idleTimeoutMsg:
+		if msg.tag == m.idleTag {
+			return m, tea.Quit
+		}
+
+		return m, nil
+	case validationTickMsg:
+		return m, m.runValidations(msg)
+	case validationResultMsg:
+		return m.applyValidation(msg), nil
+	case pasteErrorMsg:
+		m.err = msg.err
+
+		return m, nil
+	case copyResultMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.status = "Payload copied to the clipboard"
+		}
+
+		return m, nil
+	case tea.KeyMsg:
+		m.idleTag++
+		m.status = ""
+		timer := m.idleTimer()
+
+		updated, cmd := m.update(msg)
+
+		return updated, tea.Batch(cmd, timer)
+	}
+
+	return m.update(msg)
+}
+
+func (m Model) View() string {
+	var s strings.Builder
+
+	switch m.state {
+	case actionSelection:
+		m.writeActionSelection(&s)
+	case forwardingSelection:
+		m.writeForwardingSelection(&s)
+	case actionInput:
+		if d, ok := lookupActionDescriptor(m.selectedAction); ok {
+			d.write(m, &s)
+		}
+	case forwardingInput:
+		if d, ok := lookupForwardingDescriptor(m.selectedProtocol); ok {
+			d.write(m, &s)
+		}
+	case payloadConfirmation:
+		m.writeConfirmation(&s)
+	case keyringSelection:
+		m.writeKeyringSelection(&s)
+	case formatSelection:
+		m.writeFormatSelection(&s)
+	case expertInput:
+		m.writeExpertInput(&s)
+	case payloadImport:
+		m.writePayloadImport(&s)
+	default:
+		if m.err == nil {
+			m.err = errUnhandledState(m.state)
+		}
+	}
+
+	if m.err != nil {
+		s.WriteString(
+			m.styles.error.Render("\nError: " + m.err.Error()),
+		)
+	}
+
+	if m.status != "" {
+		s.WriteString(m.styles.hint.Render("\n" + m.status))
+	}
+
+	m.writeConfirm(&s)
+	m.writePalette(&s)
+
+	return s.String()
+}
+
+// selectAction shows the inputs of the registered action with the given name.
+func (m Model) selectAction(name string) (tea.Model, tea.Cmd) {
+	d, ok := lookupActionDescriptor(core.ActionID(core.ActionID_value[name]))
+	if !ok || !d.implemented {
+		m.err = fmt.Errorf("%s is %w yet", name, builder.ErrNotSupported)
+
+		return m, nil
+	}
+
+	m.selectedAction = d.id
+
+	return d.init(m), nil
+}
+
+// selectProtocol shows the inputs of the registered forwarding protocol with the given name.
+func (m Model) selectProtocol(name string) (tea.Model, tea.Cmd) {
+	d, ok := lookupForwardingDescriptor(core.ProtocolID(core.ProtocolID_value[name]))
+	if !ok || !d.implemented {
+		m.err = fmt.Errorf("%s is %w yet", name, builder.ErrNotSupported)
+
+		return m, nil
+	}
+
+	m.selectedProtocol = d.id
+
+	return d.init(m), nil
+}
+
+// update handles the different TUI states through the different
+// selection modals.
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && m.confirm != nil {
+		return m.updateConfirm(msg)
+	}
+
+	// NOTE: besides key presses, the palette receives the results of filtering its commands.
+	switch msg.(type) {
+	case tea.KeyMsg, list.FilterMatchesMsg:
+		if m.palette != nil {
+			return m.updatePalette(msg)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+c":
 			return m, tea.Quit
+		case CtrlK:
+			return m.openPalette(), nil
+		case "q":
+			// NOTE: in inputs and while filtering lists, q is entered as character.
+			if m.acceptsText() {
+				break
+			}
+
+			return m.confirmQuit(), nil
+		case CtrlV:
+			// NOTE: the clipboard is read here instead of by the inputs,
+			// so that its content is sanitized like pastes of the terminal.
+			switch m.state {
+			case actionInput, forwardingInput, expertInput, payloadImport:
+				return m, pasteFromClipboard
+			default:
+				// Lists handle pasting into their filter themselves
+			}
 		case "enter":
 			return m.handleEnter()
+		case "esc":
+			switch m.state {
+			case payloadConfirmation:
+				return m.cancelConfirmation(), nil
+			case keyringSelection:
+				return m.cancelKeyringSelection(), nil
+			case formatSelection:
+				return m.cancelFormatSelection(), nil
+			case payloadImport:
+				return m.cancelPayloadImport(), nil
+			default:
+				// Esc is handled by the inputs and lists
+			}
+		case CtrlZ:
+			if m.state == actionSelection {
+				return m.undoActions(), nil
+			}
+		case CtrlY:
+			if m.state == actionSelection {
+				return m.redoActions(), nil
+			}
+		case CtrlO:
+			if m.state == actionSelection && !m.acceptsText() {
+				return m.initPayloadImport(), nil
+			}
+		case Tab, ShiftTab:
+			switch m.state {
+			case payloadConfirmation:
+				return m.cycleReviewFocus(msg.String() == Tab), nil
+			case actionSelection, forwardingSelection, keyringSelection, formatSelection:
+				// NOTE: lists have no inputs to focus, so Tab is ignored to not
+				// change the focus index of the inputs returned to later.
+				return m, nil
+			default:
+				// Tab moves the focus between the inputs
+			}
+		case "p":
+			if m.state == payloadConfirmation {
+				return m.toggleRawPayload(), nil
+			}
+		case "x":
+			if m.state == payloadConfirmation {
+				return m.toggleHexDump(), nil
+			}
 		}
 	case tea.WindowSizeMsg:
 		m.windowWidth = msg.Width
 		m.windowHeight = msg.Height
-		m.list.SetWidth(msg.Width)
-		m.list.SetHeight(msg.Height - 8)
+		m.list = m.resizeList(m.list)
+		m.hexDump = m.resizeHexDump(m.hexDump)
+		if m.palette != nil {
+			l := m.resizePalette(*m.palette)
+			m.palette = &l
+		}
 
 		return m, nil
 	}
 
-	var cmd tea.Cmd
+	msg = sanitizePaste(msg)
+
+	var cmd, validate tea.Cmd
 	switch m.state {
-	case actionSelection, forwardingSelection:
+	case actionSelection, forwardingSelection, keyringSelection, formatSelection:
 		m.list, cmd = m.list.Update(msg)
-	case feeActionInput:
-		cmd = m.updateActionInputs(msg)
-	case cctpForwardingInput, internalForwardingInput:
-		cmd = m.updateForwardingInputs(msg)
+	case actionInput:
+		m, cmd = m.updateActionInputs(msg)
+	case forwardingInput:
+		m, cmd = m.updateForwardingInputs(msg)
+		m = m.applyEnteredDomain()
+		m, validate = m.scheduleValidations()
+	case expertInput:
+		m, cmd = m.updateExpertInputs(msg)
+		m = m.applyEnteredDomain()
+		m, validate = m.scheduleValidations()
+	case payloadImport:
+		m.importInput, cmd = m.importInput.Update(msg)
+	case payloadConfirmation:
+		// NOTE: the confirmation screen has no inputs, only the hex dump is scrollable.
+		if m.showHexDump {
+			m.hexDump, cmd = m.hexDump.Update(msg)
+		}
 	default:
-		panic(fmt.Errorf("unhandled state: %v", m.state))
+		// Surface the error instead of crashing the terminal,
+		// so that the user can still quit the program.
+		m.err = errUnhandledState(m.state)
 	}
 
-	return m, cmd
+	return m, tea.Batch(cmd, validate)
 }
 
-func (m Model) View() string {
-	var s strings.Builder
+// listItems returns the given items to be shown in a selection list.
+// Items that are not implemented are only included in experimental mode.
+func (m Model) listItems(items ...item) []list.Item {
+	listItems := make([]list.Item, 0, len(items))
+	for _, i := range items {
+		if i.implemented || m.opts.Experimental {
+			listItems = append(listItems, i)
+		}
+	}
 
+	return listItems
+}
+
+// resizeList fits the list into the stored window dimensions,
+// leaving room for the header and explanation above it.
+// The list keeps its size if no dimensions were received yet.
+func (m Model) resizeList(l list.Model) list.Model {
+	if m.windowWidth <= 0 || m.windowHeight <= 0 {
+		return l
+	}
+
+	l.SetWidth(m.windowWidth)
+	l.SetHeight(max(m.windowHeight-listHeightOffset, 0))
+
+	return l
+}
+
+// acceptsText returns whether key presses are currently entered as text,
+// which is the case for input screens and while filtering a list.
+func (m Model) acceptsText() bool {
 	switch m.state {
-	case actionSelection:
-		m.writeActionSelection(&s)
-	case forwardingSelection:
-		m.writeForwardingSelection(&s)
-	case feeActionInput:
-		m.writeFeeActionSelection(&s)
-	case cctpForwardingInput:
-		m.writeCCTPForwardingSelection(&s)
-	case internalForwardingInput:
-		m.writeInternalForwardingSelection(&s)
+	case actionInput, forwardingInput, expertInput, payloadImport:
+		return true
+	case actionSelection, forwardingSelection, keyringSelection, formatSelection:
+		return m.list.FilterState() == list.Filtering
+	default:
+		return false
 	}
+}
 
-	if m.err != nil {
-		s.WriteString(
-			errorStyle.Render("\nError: " + m.err.Error()),
-		)
+// focusInput moves the focus to the input at the given index.
+func (m Model) focusInput(inputs []textinput.Model, index int) (Model, tea.Cmd) {
+	m.focusIndex = index
+
+	cmds := make([]tea.Cmd, len(inputs))
+	for i := range inputs {
+		if i == m.focusIndex {
+			cmds[i] = inputs[i].Focus()
+		} else {
+			inputs[i].Blur()
+		}
 	}
 
-	return s.String()
+	return m, tea.Batch(cmds...)
+}
+
+// inputError sets the error for the given inputs and moves the focus to the input
+// of the field the error pertains to, so that the value can be corrected directly.
+// The fields contain the builder field of each input, in order.
+func (m Model) inputError(
+	err error,
+	inputs []textinput.Model,
+	fields []string,
+) (tea.Model, tea.Cmd) {
+	var builderErr *builder.Error
+	if !errors.As(err, &builderErr) {
+		m.err = err
+
+		return m, nil
+	}
+
+	m.err = fieldError{field: builderErr.Field, err: err}
+
+	index := slices.Index(fields, builderErr.Field)
+	if index < 0 {
+		return m, nil
+	}
+
+	return m.focusInput(inputs, index)
 }
 
 func (m Model) handleEnter() (tea.Model, tea.Cmd) {
@@ -161,36 +575,42 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			panic(fmt.Sprintf("failed to cast list item to item; got: %T", m.list.SelectedItem()))
 		}
 
-		switch selected.title {
-		case core.ACTION_FEE.String():
-			return m.initFeeActionInput(), nil
-		case core.ACTION_SWAP.String():
-			panic("not implemented yet: " + core.ACTION_SWAP.String())
-		case "No more actions":
+		if selected.value == noMoreActions {
+			if m.presetForwarding != nil {
+				return m.initConfirmation(m.presetForwarding), nil
+			}
+
 			return m.initForwardingSelection(), nil
 		}
-	case feeActionInput:
-		return m.processFeeAction()
+
+		return m.selectAction(selected.title)
+	case actionInput:
+		if d, ok := lookupActionDescriptor(m.selectedAction); ok {
+			return d.process(m)
+		}
 	case forwardingSelection:
 		selected, ok := m.list.SelectedItem().(item)
 		if !ok {
 			panic(fmt.Sprintf("failed to cast list item to item; got: %T", m.list.SelectedItem()))
 		}
 
-		switch selected.title {
-		case core.PROTOCOL_CCTP.String():
-			return m.initCCTPForwardingInput(), nil
-		case core.PROTOCOL_IBC.String():
-			panic("not supported yet: " + core.PROTOCOL_IBC.String())
-		case core.PROTOCOL_HYPERLANE.String():
-			panic("not implemented yet: " + core.PROTOCOL_HYPERLANE.String())
-		case core.PROTOCOL_INTERNAL.String():
-			return m.initInternalForwardingInput(), nil
-		}
-	case cctpForwardingInput:
-		return m.processCCTPForwarding()
-	case internalForwardingInput:
-		return m.processInternalForwarding()
+		return m.selectProtocol(selected.title)
+	case forwardingInput:
+		if d, ok := lookupForwardingDescriptor(m.selectedProtocol); ok {
+			return d.process(m)
+		}
+	case payloadConfirmation:
+		return m.processConfirmation()
+	case keyringSelection:
+		return m.processKeyringSelection()
+	case formatSelection:
+		return m.processFormatSelection()
+	case expertInput:
+		return m.processExpertInput()
+	case payloadImport:
+		return m.processPayloadImport()
+	default:
+		m.err = errUnhandledState(m.state)
 	}
 
 	return m, nil
@@ -0,0 +1,231 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+// lastState is the last state of the model, which has to be updated when adding a state.
+const lastState = payloadImport
+
+// testOptions are the options of the models in the tests, which do not depend
+// on the language and color settings of the environment.
+var testOptions = builder.Options{Language: "en", Theme: "no-color"}
+
+// testMintRecipient is the EVM address used as mint recipient in the tests.
+const testMintRecipient = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+
+func TestUpdateAndViewHandleAllStates(t *testing.T) {
+	msgs := []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")},
+		tea.KeyMsg{Type: tea.KeyTab},
+		tea.KeyMsg{Type: tea.KeyEnter},
+		tea.WindowSizeMsg{Width: 100, Height: 40},
+	}
+
+	for s := actionSelection; s <= lastState; s++ {
+		t.Run(fmt.Sprintf("state %d", s), func(t *testing.T) {
+			m := modelInState(t, s)
+
+			require.NotPanics(t, func() {
+				require.NotContains(t, m.View(), "unhandled state")
+			})
+
+			for _, msg := range msgs {
+				require.NotPanics(t, func() {
+					next := update(t, m, msg)
+					if next.err != nil {
+						require.NotContains(t, next.err.Error(), "unhandled state")
+					}
+
+					_ = next.View()
+				})
+			}
+		})
+	}
+
+	t.Run("unknown state", func(t *testing.T) {
+		m := InitialModel(builder.Options{})
+		m.state = lastState + 1
+
+		require.NotPanics(t, func() {
+			require.Contains(t, m.View(), errUnhandledState(m.state).Error())
+		})
+
+		for _, msg := range msgs[:3] {
+			require.NotPanics(t, func() {
+				next := update(t, m, msg)
+				require.EqualError(t, next.err, errUnhandledState(m.state).Error())
+			})
+		}
+	})
+}
+
+func TestWindowSizeCarriesOverToListScreens(t *testing.T) {
+	m := update(t, InitialModel(builder.Options{}), tea.WindowSizeMsg{Width: 100, Height: 40})
+	require.Equal(t, 100, m.windowWidth)
+	require.Equal(t, 40, m.windowHeight)
+	require.Equal(t, 100, m.list.Width())
+	require.Equal(t, 40-listHeightOffset, m.list.Height())
+
+	// Selecting "No more actions" moves on to the forwarding selection.
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, forwardingSelection, m.state)
+	require.Equal(t, 100, m.list.Width())
+	require.Equal(t, 40-listHeightOffset, m.list.Height())
+}
+
+func TestInputFocusResetsAfterListScreens(t *testing.T) {
+	m := enterFeeActionInput(t, InitialModel(builder.Options{}))
+	require.Equal(t, 0, m.focusIndex)
+
+	m = update(t, m, typeText(testutil.NewNobleAddress()))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m = update(t, m, typeText("100"))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	require.Equal(t, 2, m.focusIndex)
+
+	// Submitting the fee action returns to the action selection, which ignores Tab.
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	require.Equal(t, actionSelection, m.state)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	require.Equal(t, 2, m.focusIndex)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, actionInput, m.state)
+	require.Equal(t, 0, m.focusIndex)
+	require.True(t, m.actionInputs[0].Focused())
+
+	// The forwarding inputs start at the first input as well.
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	require.Equal(t, 1, m.focusIndex)
+
+	m = enterCCTPForwardingInput(t, m)
+	require.Equal(t, 0, m.focusIndex)
+	require.True(t, m.forwardingInputs[0].Focused())
+}
+
+// modelInState returns a model, that entered the given state like it would through the UI.
+//
+// NOTE: the switch lists all states, so that the exhaustive linter reports new states,
+// which have to be added here to be covered by the tests.
+func modelInState(t *testing.T, s state) Model {
+	t.Helper()
+
+	m := InitialModel(testOptions)
+	switch s {
+	case actionSelection:
+		return m
+	case actionInput:
+		return enterFeeActionInput(t, m)
+	case forwardingSelection:
+		return m.initForwardingSelection()
+	case forwardingInput:
+		return enterCCTPForwardingInput(t, m)
+	case payloadConfirmation:
+		return m.initConfirmation(testForwarding(t))
+	case keyringSelection:
+		// NOTE: the keyring is not available in the tests, so the selection is filled directly.
+		m = enterFeeActionInput(t, m)
+		m.list = list.New(
+			[]list.Item{item{title: "test", value: testutil.NewNobleAddress(), implemented: true}},
+			list.NewDefaultDelegate(),
+			0,
+			0,
+		)
+		m.keyringReturnState = m.state
+		m.state = keyringSelection
+
+		return m
+	case formatSelection:
+		m.forwarding = testForwarding(t)
+
+		return m.initFormatSelection()
+	case expertInput:
+		return m.initExpertInput()
+	case payloadImport:
+		return m.initPayloadImport()
+	default:
+		t.Fatalf("no model for state %d", s)
+
+		return m
+	}
+}
+
+// enterFeeActionInput selects the fee action in the action selection.
+func enterFeeActionInput(t *testing.T, m Model) Model {
+	t.Helper()
+
+	updated, _ := m.selectAction(core.ACTION_FEE.String())
+	next, ok := updated.(Model)
+	require.True(t, ok, "expected the model; got: %T", updated)
+	require.Equal(t, actionInput, next.state)
+
+	return next
+}
+
+// enterCCTPForwardingInput selects the CCTP protocol in the forwarding selection.
+func enterCCTPForwardingInput(t *testing.T, m Model) Model {
+	t.Helper()
+
+	updated, _ := m.initForwardingSelection().selectProtocol(core.PROTOCOL_CCTP.String())
+	next, ok := updated.(Model)
+	require.True(t, ok, "expected the model; got: %T", updated)
+	require.Equal(t, forwardingInput, next.state)
+
+	return next
+}
+
+// testForwarding returns a valid CCTP forwarding to Base.
+func testForwarding(t *testing.T) *core.Forwarding {
+	t.Helper()
+
+	fwd, err := builder.NewCCTPForwarding(builder.Options{}, 6, testMintRecipient, "", "")
+	require.NoError(t, err)
+
+	return fwd
+}
+
+// update passes the message to the model and returns the updated model.
+func update(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+
+	updated, _ := m.Update(msg)
+	next, ok := updated.(Model)
+	require.True(t, ok, "expected the model; got: %T", updated)
+
+	return next
+}
+
+// typeText returns the key press, that enters the given text into the focused input.
+func typeText(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}
+}
@@ -0,0 +1,251 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"errors"
+	"maps"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+// validationDelay is the pause in typing, after which the deferred validations are run.
+const validationDelay = 500 * time.Millisecond
+
+// deferredValidation is an expensive validation of a forwarding input, like resolving
+// an ENS name, which is only run once the user paused typing instead of on every key press.
+// Cheap validations are run synchronously when rendering or submitting the inputs instead.
+type deferredValidation struct {
+	// input is the index of the validated forwarding input.
+	input int
+	// applies cheaply checks whether the value requires the validation.
+	applies func(opts builder.Options, value string) bool
+	// validate runs the validation and returns the value the input resolves to,
+	// which is submitted instead of the entered value.
+	validate func(opts builder.Options, value string) (string, error)
+}
+
+// validationResult is the result of a deferred validation of the contained value.
+type validationResult struct {
+	value    string
+	pending  bool
+	resolved string
+	err      error
+}
+
+// errValidating is reported when submitting inputs, whose deferred validation is still running.
+var errValidating = errors.New("validating...")
+
+// validationTickMsg is sent when the user paused typing.
+// It only runs the validations if no input changed since the tick was scheduled.
+type validationTickMsg struct {
+	tag int
+}
+
+// validationResultMsg contains the result of a deferred validation of the given input.
+type validationResultMsg struct {
+	input  int
+	result validationResult
+}
+
+// ensValidation resolves ENS names entered as mint recipient of a CCTP forwarding.
+var ensValidation = deferredValidation{
+	input: 1,
+	applies: func(opts builder.Options, value string) bool {
+		return opts.ENSRPC != "" && builder.IsENSName(value)
+	},
+	validate: func(opts builder.Options, value string) (string, error) {
+		return builder.ResolveENSAddress(opts, value)
+	},
+}
+
+// scheduleValidations marks the results of changed inputs as pending and restarts
+// the validation timer, so that the validations are run once the user paused typing.
+func (m Model) scheduleValidations() (Model, tea.Cmd) {
+	d, ok := lookupForwardingDescriptor(m.selectedProtocol)
+	if !ok {
+		return m, nil
+	}
+
+	changed := false
+	validations := maps.Clone(m.validations)
+	for _, v := range d.deferred {
+		if v.input >= len(m.forwardingInputs) {
+			continue
+		}
+
+		value := strings.TrimSpace(m.forwardingInputs[v.input].Value())
+		if r, found := validations[v.input]; found && r.value == value {
+			continue
+		}
+
+		if validations == nil {
+			validations = make(map[int]validationResult)
+		}
+
+		if !v.applies(m.opts, value) {
+			delete(validations, v.input)
+
+			continue
+		}
+
+		validations[v.input] = validationResult{value: value, pending: true}
+		changed = true
+	}
+
+	m.validations = validations
+	if !changed {
+		return m, nil
+	}
+
+	m.validationTag++
+	tag := m.validationTag
+
+	return m, tea.Tick(validationDelay, func(time.Time) tea.Msg {
+		return validationTickMsg{tag: tag}
+	})
+}
+
+// runValidations runs all pending validations in the background.
+func (m Model) runValidations(msg validationTickMsg) tea.Cmd {
+	d, ok := lookupForwardingDescriptor(m.selectedProtocol)
+	if msg.tag != m.validationTag || !ok {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0, len(d.deferred))
+	for _, v := range d.deferred {
+		r, found := m.validations[v.input]
+		if !found || !r.pending {
+			continue
+		}
+
+		cmds = append(cmds, v.run(m.opts, r.value))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// run returns the command, that runs the validation of the given value in the background.
+func (v deferredValidation) run(opts builder.Options, value string) tea.Cmd {
+	return func() tea.Msg {
+		resolved, err := v.validate(opts, value)
+
+		return validationResultMsg{
+			input:  v.input,
+			result: validationResult{value: value, resolved: resolved, err: err},
+		}
+	}
+}
+
+// applyValidation stores the result of a validation,
+// unless the input was changed while it was running.
+func (m Model) applyValidation(msg validationResultMsg) Model {
+	r, found := m.validations[msg.input]
+	if !found || r.value != msg.result.value {
+		return m
+	}
+
+	m.validations = maps.Clone(m.validations)
+	m.validations[msg.input] = msg.result
+
+	return m
+}
+
+// awaitValidations checks the deferred validations before submitting the inputs,
+// so that they are never run synchronously. Inputs without a result for their current value,
+// e.g. because they were prefilled, are validated in the background right away.
+// Submitting is blocked until all results are available, or if any of them failed.
+func (m Model) awaitValidations(d forwardingDescriptor) (Model, tea.Cmd, bool) {
+	var (
+		cmds    []tea.Cmd
+		pending error
+	)
+	for _, v := range d.deferred {
+		if v.input >= len(m.forwardingInputs) {
+			continue
+		}
+
+		value := strings.TrimSpace(m.forwardingInputs[v.input].Value())
+		if !v.applies(m.opts, value) {
+			continue
+		}
+
+		r, found := m.validations[v.input]
+		if !found || r.value != value {
+			r = validationResult{value: value, pending: true}
+			m.validations = maps.Clone(m.validations)
+			if m.validations == nil {
+				m.validations = make(map[int]validationResult)
+			}
+			m.validations[v.input] = r
+			cmds = append(cmds, v.run(m.opts, value))
+		}
+
+		switch {
+		case r.err != nil:
+			m.err = fieldError{field: d.fields[v.input], err: r.err}
+
+			return m, tea.Batch(cmds...), true
+		case r.pending && pending == nil:
+			pending = fieldError{field: d.fields[v.input], err: errValidating}
+		}
+	}
+
+	if pending == nil {
+		return m, nil, false
+	}
+
+	m.err = pending
+
+	return m, tea.Batch(cmds...), true
+}
+
+// validatedValue returns the trimmed value of the given forwarding input,
+// or the value it resolved to, if its deferred validation succeeded.
+func (m Model) validatedValue(input int) string {
+	value := strings.TrimSpace(m.forwardingInputs[input].Value())
+
+	r, found := m.validations[input]
+	if !found || r.pending || r.err != nil || r.value != value {
+		return value
+	}
+
+	return r.resolved
+}
+
+// writeValidationHint shows the result of the deferred validation of the given input.
+func (m Model) writeValidationHint(s *strings.Builder, input int) {
+	r, found := m.validations[input]
+	switch {
+	case !found:
+		return
+	case r.pending:
+		s.WriteString(m.styles.hint.Render("  (validating...)"))
+	case r.err != nil:
+		s.WriteString(m.styles.error.Render("  " + r.err.Error()))
+	default:
+		s.WriteString(m.styles.hint.Render("  = " + r.resolved))
+	}
+
+	s.WriteString("\n")
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+func TestSubmitUsesCompletedENSResolution(t *testing.T) {
+	const name = "name.eth"
+
+	opts := testOptions
+	// NOTE: the endpoint is never called, because the tests do not run the returned commands.
+	opts.ENSRPC = "http://127.0.0.1:0"
+
+	m := enterCCTPForwardingInput(t, InitialModel(opts))
+	m = update(t, m, typeText("6"))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m = update(t, m, typeText(name))
+	require.True(t, m.validations[1].pending)
+
+	// Submitting is blocked until the name is resolved.
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.ErrorIs(t, m.err, errValidating)
+	require.Equal(t, forwardingInput, m.state)
+
+	m = update(t, m, validationResultMsg{
+		input:  1,
+		result: validationResult{value: name, resolved: testMintRecipient},
+	})
+	require.Contains(t, m.View(), "= "+testMintRecipient)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NoError(t, m.err)
+	require.Equal(t, payloadConfirmation, m.state)
+
+	expected, err := builder.BuildPayload(testForwarding(t), nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, m.reviewPayload)
+}
+
+func TestSubmitResolvesPrefilledENSNameInBackground(t *testing.T) {
+	domain := uint32(6)
+
+	opts := testOptions
+	opts.ENSRPC = "http://127.0.0.1:0"
+	opts.Profile.DestinationDomain = &domain
+	opts.Profile.MintRecipient = "name.eth"
+
+	m := enterCCTPForwardingInput(t, InitialModel(opts))
+	require.Empty(t, m.validations)
+
+	// The prefilled name is resolved by the returned command instead of blocking the update.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, ok := updated.(Model)
+	require.True(t, ok, "expected the model; got: %T", updated)
+	require.ErrorIs(t, m.err, errValidating)
+	require.True(t, m.validations[1].pending)
+	require.NotNil(t, cmd)
+
+	// NOTE: resolving fails without reaching the network, because the port is invalid.
+	msg, ok := cmd().(validationResultMsg)
+	require.True(t, ok, "expected the validation result")
+	require.Error(t, msg.result.err)
+
+	m = update(t, m, msg)
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.ErrorIs(t, m.err, msg.result.err)
+	require.Equal(t, forwardingInput, m.state)
+}
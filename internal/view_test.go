@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGolden rewrites the golden files with the rendered views instead of comparing them,
+// e.g. after intended changes to the UI: go test ./internal -run TestViewGolden -update
+var updateGolden = flag.Bool("update", false, "update the golden files of the rendered views")
+
+// goldenWidth and goldenHeight are the window dimensions of the rendered views.
+const (
+	goldenWidth  = 100
+	goldenHeight = 30
+)
+
+func TestViewGolden(t *testing.T) {
+	testCases := []struct {
+		name  string
+		state state
+		// keys are pressed after entering the state, e.g. to submit empty inputs.
+		keys []tea.KeyMsg
+	}{
+		{name: "action_selection", state: actionSelection},
+		{name: "fee_action_input", state: actionInput},
+		{name: "cctp_forwarding_input", state: forwardingInput},
+		{
+			name:  "fee_action_input_error",
+			state: actionInput,
+			keys:  []tea.KeyMsg{{Type: tea.KeyEnter}},
+		},
+		{
+			name:  "cctp_forwarding_input_error",
+			state: forwardingInput,
+			keys:  []tea.KeyMsg{typeText("4"), {Type: tea.KeyEnter}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			view := viewInState(t, tc.state, tc.keys...)
+
+			path := filepath.Join("testdata", tc.name+".golden")
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll("testdata", 0o750))
+				require.NoError(t, os.WriteFile(path, []byte(view), 0o600))
+			}
+
+			expected, err := os.ReadFile(path)
+			require.NoError(t, err, "run with -update to create the golden file")
+			require.Equal(t, string(expected), view)
+		})
+	}
+}
+
+// viewInState renders the view of a model in the given state with fixed window dimensions,
+// after pressing the given keys.
+func viewInState(t *testing.T, s state, keys ...tea.KeyMsg) string {
+	t.Helper()
+
+	m := update(t, modelInState(t, s), tea.WindowSizeMsg{Width: goldenWidth, Height: goldenHeight})
+	for _, key := range keys {
+		m = update(t, m, key)
+	}
+
+	return m.View()
+}
@@ -18,37 +18,1216 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/noble-assets/orbiter/testutil"
+	"github.com/noble-assets/orbiter/types/core"
 
 	"github.com/noble-assets/orbgen/internal"
+	"github.com/noble-assets/orbgen/internal/builder"
 )
 
+// simulateTimeout is the maximum duration of querying the account and simulating the transaction.
+const simulateTimeout = 30 * time.Second
+
 func main() {
+	specPath := flag.String(
+		"spec",
+		"",
+		"generate the payload from the given JSON spec file instead of running the TUI",
+	)
+	printSchema := flag.Bool(
+		"print-schema",
+		false,
+		"print the JSON schema of the spec file format and exit",
+	)
+	ensRPC := flag.String(
+		"ens-rpc",
+		"",
+		"Ethereum RPC endpoint used to resolve ENS names (e.g. name.eth) for mint recipients",
+	)
+	maxPassthroughSize := flag.Uint(
+		"max-passthrough-size",
+		0,
+		"maximum passthrough payload size in bytes, as configured on-chain; replaces the default of 64 KiB, but never exceeds a known destination limit",
+	)
+	passthroughABIPath := flag.String(
+		"passthrough-abi",
+		"",
+		"contract ABI file, whose function calls CCTP passthrough payloads are validated and decoded against",
+	)
+	importPath := flag.String(
+		"import",
+		"",
+		"preload the actions and forwarding of the given payload file in the TUI to append actions",
+	)
+	query := flag.String(
+		"query",
+		"",
+		"preview the payload described by a shared query string (e.g. 'orbgen?protocol=cctp&domain=6&recipient=0x...') in the TUI",
+	)
+	expectPath := flag.String(
+		"expect",
+		"",
+		"compare the generated payload to the given payload file instead of printing it, and exit with a diff if they differ",
+	)
+	editPath := flag.String(
+		"edit",
+		"",
+		"append the actions given with --add-fee to the given payload file and write it back",
+	)
+	var fees feeFlag
+	flag.Var(
+		&fees,
+		"add-fee",
+		"fee recipient:basis-points (or percentage) to append with --edit; can be repeated",
+	)
+	telemetryTarget := flag.String(
+		"telemetry",
+		os.Getenv(builder.TelemetryEnv),
+		"opt in to append an event without addresses describing each generated payload to the given file or socket",
+	)
+	recordPath := flag.String(
+		"record",
+		"",
+		"write a plain text transcript of the screens shown in the TUI to the given file",
+	)
+	diffMode := flag.Bool(
+		"diff",
+		false,
+		"compare the two payload files passed as arguments; exits with 1 if they differ",
+	)
+	strict := flag.Bool(
+		"strict",
+		false,
+		"only accept addresses of exactly 32 bytes, or 20 bytes for EVM domains, and whole basis points",
+	)
+	mintRecipientPadding := flag.String(
+		"mint-recipient-padding",
+		string(builder.PaddingAuto),
+		fmt.Sprintf(
+			"alignment of CCTP mint recipients shorter than 32 bytes; one of %v (auto left-pads)",
+			builder.Paddings,
+		),
+	)
+	destCallerPadding := flag.String(
+		"destination-caller-padding",
+		string(builder.PaddingAuto),
+		fmt.Sprintf(
+			"alignment of CCTP destination callers shorter than 32 bytes; one of %v (auto left-pads)",
+			builder.Paddings,
+		),
+	)
+	allowZeroRecipient := flag.Bool(
+		"allow-zero-recipient",
+		false,
+		"accept the zero address as CCTP mint recipient, which makes the funds unrecoverable",
+	)
+	experimental := flag.Bool(
+		"experimental",
+		false,
+		"list actions and protocols in the TUI, that are not supported yet",
+	)
+	expert := flag.Bool(
+		"expert",
+		false,
+		"show the forwarding and all fee actions on a single screen in the TUI",
+	)
+	forwardingFirst := flag.Bool(
+		"forwarding-first",
+		false,
+		"configure the forwarding before selecting the actions in the TUI",
+	)
+	keyringDir := flag.String(
+		"keyring-dir",
+		"",
+		"directory of the keyring to select recipient addresses from in the TUI",
+	)
+	keyringBackend := flag.String("keyring-backend", "test", "backend of the keyring; os or test")
+	idleTimeout := flag.Duration(
+		"timeout",
+		0,
+		"quit the TUI without building a payload after this duration without key presses (e.g. 5m)",
+	)
+	profileName := flag.String(
+		"profile",
+		"",
+		"load the default values and bech32 prefix of the given profile (e.g. mainnet) from the config directory",
+	)
+	language := flag.String(
+		"lang",
+		"",
+		fmt.Sprintf(
+			"language of the TUI; one of %v (default respects LC_ALL, LC_MESSAGES and LANG)",
+			internal.LanguageNames(),
+		),
+	)
+	themeName := flag.String(
+		"theme",
+		"",
+		fmt.Sprintf(
+			"theme of the TUI; one of %v (default respects NO_COLOR)",
+			internal.ThemeNames(),
+		),
+	)
+	formatName := flag.String(
+		"format",
+		string(builder.FormatRaw),
+		fmt.Sprintf(
+			"output format of the generated payload; one of %v, or a comma-separated list to print several",
+			builder.Formats,
+		),
+	)
+	jsonCompact := flag.Bool(
+		"json-compact",
+		false,
+		"output JSON on a single line instead of indented",
+	)
+	quiet := flag.Bool(
+		"quiet",
+		false,
+		"only print the bare payload without labels, which requires a single --format",
+	)
+	metadata := make(metadataFlag)
+	flag.Var(
+		metadata,
+		"meta",
+		"metadata key=value to include in the JSON output; can be repeated",
+	)
+	outSocket := flag.String(
+		"out-socket",
+		"",
+		"write the payload to the given unix socket or named pipe instead of stdout (non-interactive only)",
+	)
+	recipientsPath := flag.String(
+		"recipients",
+		"",
+		"generate one payload per mint recipient in the given file (one per line), based on the spec or arguments",
+	)
+	junitPath := flag.String(
+		"junit",
+		"",
+		"write a JUnit XML report with one test case per recipient to the given file (--recipients only)",
+	)
+	txMode := flag.Bool(
+		"tx",
+		false,
+		"output an unsigned ICS-20 transfer transaction to the orbiter, carrying the payload as memo",
+	)
+	txSender := flag.String("tx-sender", "", "sender address of the transfer on the source chain")
+	txAmount := flag.String("tx-amount", "", "amount and denom of the transfer (e.g. 1000000uusdc)")
+	txChannel := flag.String(
+		"tx-channel",
+		"",
+		"IBC channel on the source chain, that is connected to Noble (e.g. channel-0)",
+	)
+	txTimeout := flag.Duration("tx-timeout", 10*time.Minute, "timeout of the transfer")
+	simulateAddr := flag.String(
+		"simulate",
+		"",
+		"simulate the --tx transaction against the gRPC endpoint of a source chain node (prefix with https:// for TLS)",
+	)
+	broadcastCommand := flag.String(
+		"broadcast",
+		"",
+		"sign and broadcast the --tx transaction with the given command, which receives the unsigned transaction file in place of {} (asks for confirmation)",
+	)
+	broadcastYes := flag.Bool(
+		"broadcast-yes",
+		false,
+		"broadcast with --broadcast without asking for confirmation",
+	)
+	flag.Usage = usage
+	flag.Parse()
+
+	if *maxPassthroughSize > math.MaxUint32 {
+		log.Fatal("--max-passthrough-size exceeds the maximum of 32 bit values")
+	}
+
+	if err := internal.ParseTheme(*themeName); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := internal.ParseLanguage(*language); err != nil {
+		log.Fatal(err)
+	}
+
+	mintPadding, err := builder.ParsePadding(*mintRecipientPadding)
+	if err != nil {
+		log.Fatal(fmt.Errorf("invalid --mint-recipient-padding: %w", err))
+	}
+
+	callerPadding, err := builder.ParsePadding(*destCallerPadding)
+	if err != nil {
+		log.Fatal(fmt.Errorf("invalid --destination-caller-padding: %w", err))
+	}
+
+	formats, err := builder.ParseFormats(*formatName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *quiet && len(formats) > 1 {
+		log.Fatal("--quiet requires a single --format; got: " + *formatName)
+	}
+
+	format := formats[0]
+	if *txMode && (len(formats) > 1 || format != builder.FormatRaw) {
+		log.Fatal("--tx cannot be combined with --format " + *formatName)
+	}
+
+	if *simulateAddr != "" && !*txMode {
+		log.Fatal("--simulate requires --tx")
+	}
+
+	if *broadcastCommand != "" && !*txMode {
+		log.Fatal("--broadcast requires --tx")
+	}
+
+	if *broadcastYes && *broadcastCommand == "" {
+		log.Fatal("--broadcast-yes requires --broadcast")
+	}
+
+	if *forwardingFirst && *expert {
+		log.Fatal("--forwarding-first cannot be combined with --expert")
+	}
+
+	if len(metadata) > 0 && !slices.Contains(formats, builder.FormatJSON) {
+		log.Fatal("--meta requires --format json")
+	}
+
+	opts := builder.Options{
+		ENSRPC:                   *ensRPC,
+		MaxPassthroughSize:       uint32(*maxPassthroughSize),
+		Strict:                   *strict,
+		MintRecipientPadding:     mintPadding,
+		DestinationCallerPadding: callerPadding,
+		AllowZeroRecipient:       *allowZeroRecipient,
+		Experimental:             *experimental,
+		Expert:                   *expert,
+		ForwardingFirst:          *forwardingFirst,
+		KeyringBackend:           *keyringBackend,
+		KeyringDir:               *keyringDir,
+		IdleTimeout:              *idleTimeout,
+		Theme:                    *themeName,
+		Language:                 *language,
+	}
+
 	// NOTE: this is required to be called to correctly set the bech32 prefix
 	testutil.SetSDKConfig()
 
+	if *profileName != "" {
+		opts.Profile, err = builder.LoadProfile(*profileName)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts.Profile.ApplyBech32Prefix()
+	}
+
+	cfg, err := builder.LoadConfig()
+	if err != nil {
+		log.Printf("warning: %v", err)
+	}
+
+	opts.KnownCallers, err = builder.MergeKnownCallers(cfg.KnownCallers, opts.Profile.KnownCallers)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *passthroughABIPath != "" {
+		opts.PassthroughABI, err = builder.LoadABI(*passthroughABIPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *printSchema {
+		schema, err := builder.SpecSchema()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(string(schema))
+
+		return
+	}
+
+	if *diffMode {
+		runDiff(flag.Args())
+
+		return
+	}
+
+	if *editPath != "" || len(fees) > 0 {
+		if *editPath == "" || len(fees) == 0 {
+			log.Fatal("--edit and --add-fee have to be used together")
+		}
+
+		if flag.NArg() > 0 || *specPath != "" || *importPath != "" || *query != "" {
+			log.Fatal("--edit cannot be combined with other payload sources")
+		}
+
+		runEdit(*editPath, fees, opts)
+
+		return
+	}
+
+	switch flag.Arg(0) {
+	case "completion":
+		printCompletion(flag.Args()[1:])
+
+		return
+	case "validate":
+		runValidate(flag.Args()[1:], opts)
+
+		return
+	case "doctor":
+		runDoctor(flag.Args()[1:])
+
+		return
+	}
+
+	out := outputConfig{
+		formats: formats,
+		options: builder.OutputOptions{
+			Format:       format,
+			Compact:      *jsonCompact,
+			Metadata:     metadata,
+			KnownCallers: opts.KnownCallers,
+		},
+	}
+	if *txMode {
+		out.tx = &builder.TransferTxConfig{
+			Sender:        *txSender,
+			Amount:        *txAmount,
+			SourceChannel: *txChannel,
+			Timeout:       *txTimeout,
+		}
+	}
+
+	args := flag.Args()
+	if flag.Arg(0) == "recent" {
+		recent, edit := selectRecent(args[1:])
+		if recent == nil {
+			return
+		}
+
+		if !edit {
+			output, err := out.render(recent.Payload)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Println(output)
+
+			return
+		}
+
+		if *importPath != "" || *specPath != "" {
+			log.Fatal("recent --edit cannot be combined with --import or --spec")
+		}
+
+		opts.Imported, err = builder.DecodePayload(recent.Payload)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		args = nil
+	}
+
+	var spec *builder.Spec
+	switch {
+	case *specPath != "" && len(args) > 0:
+		log.Fatal("--spec cannot be combined with positional arguments")
+	case *importPath != "" && (*specPath != "" || len(args) > 0):
+		log.Fatal("--import cannot be combined with a spec file or positional arguments")
+	case *query != "" && (*specPath != "" || len(args) > 0 || *importPath != "" || opts.Imported != nil):
+		log.Fatal("--query cannot be combined with a spec file, positional arguments or imported payloads")
+	case *specPath != "":
+		spec = loadSpec(*specPath)
+		// NOTE: metadata passed as flags takes precedence over the spec file.
+		for key, value := range spec.Metadata {
+			if _, found := metadata[key]; !found {
+				metadata[key] = value
+			}
+		}
+	case len(args) > 0:
+		spec = specFromArgs(args)
+	case *outSocket != "":
+		log.Fatal("--out-socket requires a spec file or positional arguments")
+	case *recipientsPath != "":
+		log.Fatal("--recipients requires a spec file or positional arguments")
+	case *expectPath != "":
+		log.Fatal("--expect requires a spec file or positional arguments")
+	case (*importPath != "" || *query != "" || opts.Imported != nil) && (*expert || *forwardingFirst):
+		log.Fatal("imported payloads cannot be edited with --expert or --forwarding-first")
+	case *query != "":
+		opts.Imported = importQuery(*query, opts)
+		opts.Review = true
+	case *importPath != "":
+		opts.Imported, err = builder.DecodePayloadFile(*importPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *recipientsPath != "" {
+		if *expectPath != "" {
+			log.Fatal("--recipients cannot be combined with --expect")
+		}
+
+		if *outSocket != "" {
+			log.Fatal("--recipients cannot be combined with --out-socket")
+		}
+
+		if *simulateAddr != "" {
+			log.Fatal("--recipients cannot be combined with --simulate")
+		}
+
+		if *broadcastCommand != "" {
+			log.Fatal("--recipients cannot be combined with --broadcast")
+		}
+
+		runFanOut(*spec, opts, *recipientsPath, *junitPath, out)
+
+		return
+	}
+
+	if *junitPath != "" {
+		log.Fatal("--junit requires --recipients")
+	}
+
+	var payload string
+	if spec != nil {
+		payload, err = spec.Build(opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		out.options.ActionNotes = spec.ActionNotes()
+
+		if *expectPath != "" {
+			checkExpected(payload, *expectPath)
+
+			return
+		}
+	} else {
+		payload, out.options.Format, out.options.ActionNotes = runInteractive(
+			opts,
+			cfg,
+			format,
+			*txMode || len(metadata) > 0,
+			*recordPath,
+		)
+	}
+
+	if err = builder.AddRecentPayload(payload); err != nil {
+		log.Printf("warning: failed to remember payload: %v", err)
+	}
+
+	if *telemetryTarget != "" {
+		mode := "interactive"
+		switch {
+		case *specPath != "":
+			mode = "spec"
+		case spec != nil:
+			mode = "args"
+		}
+
+		recordTelemetry(*telemetryTarget, mode, payload)
+	}
+
+	output, err := out.render(payload)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *simulateAddr != "" {
+		simulateTransfer(*simulateAddr, payload, *out.tx)
+	}
+
+	if *outSocket != "" {
+		if err = builder.WriteToEndpoint(*outSocket, output); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		printOutput(os.Stdout, output)
+	}
+
+	// NOTE: the transaction is broadcast after it was output,
+	// so that it can be inspected before confirming the broadcast.
+	if *broadcastCommand != "" {
+		broadcastTransfer(*broadcastCommand, output, *broadcastYes)
+	}
+}
+
+// printOutput prints the output of the generated payload on its own line.
+//
+// NOTE: the payload is printed after the TUI exited rather than within it,
+// so that it is not truncated to the size of the window and can be copied in full.
+func printOutput(w io.Writer, output string) {
+	fmt.Fprintln(w, output)
+}
+
+// broadcastTransfer runs the broadcast command with the unsigned transfer transaction
+// and reports the transaction hash on stderr. Unless confirmed is set, the user is asked
+// for confirmation first, because the broadcast transfers real funds.
+func broadcastTransfer(command, tx string, confirmed bool) {
+	args := strings.Fields(command)
+	if !confirmed {
+		fmt.Fprintf(
+			os.Stderr,
+			"Broadcast the transaction above by running %q? This transfers real funds. [y/N] ",
+			strings.Join(args, " "),
+		)
+
+		answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			// NOTE: the answer was not terminated by the user, so the line is ended here.
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+			log.Fatal("broadcast aborted")
+		}
+	}
+
+	hash, err := builder.BroadcastTx(context.Background(), args, tx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "broadcast succeeded; tx hash: %s\n", hash)
+}
+
+// recordTelemetry records the telemetry event of the generated payload.
+// Failures are only reported as warnings, to not prevent using the payload.
+func recordTelemetry(target, mode, payload string) {
+	event, err := builder.NewTelemetryEvent(mode, payload)
+	if err == nil {
+		err = builder.RecordTelemetry(target, event)
+	}
+
+	if err != nil {
+		log.Printf("warning: failed to record telemetry: %v", err)
+	}
+}
+
+// outputConfig configures how generated payloads are output.
+type outputConfig struct {
+	// formats contains the output formats, if several were given.
+	// Otherwise, the payload is output in the format of the options.
+	formats []builder.Format
+	options builder.OutputOptions
+	// tx wraps the payloads in a transfer transaction if set.
+	tx *builder.TransferTxConfig
+}
+
+// render returns the output for the given payload.
+func (c outputConfig) render(payload string) (string, error) {
+	if c.tx != nil {
+		return builder.BuildTransferTx(payload, *c.tx)
+	}
+
+	if len(c.formats) > 1 {
+		return builder.FormatPayloadLabeled(payload, c.formats, c.options)
+	}
+
+	return builder.FormatPayload(payload, c.options)
+}
+
+// simulateTransfer simulates the transfer transaction carrying the payload
+// and reports the consumed gas on stderr, exiting if the simulation fails.
+func simulateTransfer(address, payload string, cfg builder.TransferTxConfig) {
+	ctx, cancel := context.WithTimeout(context.Background(), simulateTimeout)
+	res, err := builder.SimulateTransferTx(ctx, address, payload, cfg)
+	cancel()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "simulation succeeded; gas used: %d\n", res.GasUsed)
+}
+
+// runFanOut prints one output per mint recipient in the given file, based on the spec.
+// Recipients that fail are reported on stderr without aborting the run,
+// and the program exits with a non-zero code at the end if any of them failed.
+// If a JUnit path is given, a report with one test case per recipient is written to it.
+func runFanOut(
+	spec builder.Spec,
+	opts builder.Options,
+	path, junitPath string,
+	out outputConfig,
+) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal(fmt.Errorf("failed to open recipients file: %w", err))
+	}
+	defer f.Close()
+
+	var (
+		total, failed int
+		// NOTE: the cases are only collected if a report is requested,
+		// so that recipients are otherwise processed with constant memory.
+		cases []builder.JUnitCase
+	)
+	err = spec.FanOut(opts, f, func(res builder.FanOutResult) {
+		total++
+
+		output, err := res.Payload, res.Err
+		if err == nil {
+			output, err = out.render(res.Payload)
+		}
+
+		if junitPath != "" {
+			c := builder.JUnitCase{Name: fmt.Sprintf("line %d (%s)", res.Line, res.Recipient)}
+			if err != nil {
+				c.Failure = err.Error()
+			}
+			cases = append(cases, c)
+		}
+
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "line %d (%s): %v\n", res.Line, res.Recipient, err)
+
+			return
+		}
+
+		fmt.Println(output)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if junitPath != "" {
+		if err = builder.WriteJUnitReport(junitPath, "orbgen recipients", cases); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d recipients failed\n", failed, total)
+		os.Exit(1)
+	}
+}
+
+// runDiff prints the differences between the two given payload files
+// and exits with a non-zero code if they differ.
+func runDiff(args []string) {
+	if len(args) != 2 {
+		log.Fatal("--diff requires exactly two payload files as arguments")
+	}
+
+	a, err := builder.DecodePayloadFile(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	b, err := builder.DecodePayloadFile(args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	diff, err := builder.DiffPayloads(a, b)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(diff) > 0 {
+		fmt.Println(strings.Join(diff, "\n"))
+		os.Exit(1)
+	}
+}
+
+// checkExpected compares the generated payload to the payload stored in the given file.
+// It prints the differences and exits with a non-zero code if they differ.
+func checkExpected(payload, path string) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(fmt.Errorf("failed to read expected payload: %w", err))
+	}
+
+	expected, err := builder.DecodePayload(string(bz))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	generated, err := builder.DecodePayload(payload)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	diff, err := builder.DiffPayloads(expected, generated)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// NOTE: payloads with equal fields can still differ in their encoding (e.g. the field order),
+	// which changes the memo of the transfer.
+	if len(diff) == 0 && strings.TrimSpace(string(bz)) != payload {
+		diff = append(diff, "~ payload: fields are equal, but the encoding differs")
+	}
+
+	if len(diff) > 0 {
+		fmt.Println(strings.Join(diff, "\n"))
+		os.Exit(1)
+	}
+}
+
+// runEdit appends fee actions to the payload stored in the given file and writes it back.
+// The file is only replaced once the resulting payload was built successfully.
+func runEdit(path string, fees []string, opts builder.Options) {
+	wrapper, err := builder.DecodePayloadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	actions := slices.Clone(wrapper.Orbiter.PreActions)
+	for _, entry := range fees {
+		fee, err := builder.ParseFee(opts, entry)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		act, err := builder.NewFeeAction(fee.Recipient, fee.BasisPoints)
+		if err != nil {
+			log.Fatal(fmt.Errorf("invalid fee %q: %w", entry, err))
+		}
+
+		actions = append(actions, act)
+	}
+
+	payload, err := builder.BuildPayload(wrapper.Orbiter.Forwarding, actions)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = builder.WritePayloadFile(path, payload); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runValidate validates the spec file passed with the --spec flag of the validate command
+// and reports the results, exiting with a non-zero code if the spec is invalid.
+func runValidate(args []string, opts builder.Options) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	specPath := fs.String("spec", "", "spec file to validate")
+	jsonOutput := fs.Bool("json", false, "report the results as JSON")
+	junitPath := fs.String("junit", "", "write a JUnit XML report of the results to the given file")
+	_ = fs.Parse(args) // NOTE: errors exit the program due to flag.ExitOnError
+
+	if *specPath == "" || fs.NArg() > 0 {
+		log.Fatal("usage: orbgen validate --spec <file> [--json] [--junit <file>]")
+	}
+
+	var results []builder.ValidationResult
+	if spec, err := builder.LoadSpec(*specPath); err != nil {
+		results = []builder.ValidationResult{{Path: "spec", Error: err.Error()}}
+	} else {
+		results = spec.Validate(opts)
+	}
+
+	valid := true
+	cases := make([]builder.JUnitCase, 0, len(results))
+	for _, result := range results {
+		valid = valid && result.Valid
+		cases = append(cases, builder.JUnitCase{
+			Name:    validationName(result),
+			Failure: result.Error,
+		})
+	}
+
+	if *junitPath != "" {
+		if err := builder.WriteJUnitReport(*junitPath, "orbgen validate", cases); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *jsonOutput {
+		bz, err := json.MarshalIndent(struct {
+			Valid   bool                       `json:"valid"`
+			Results []builder.ValidationResult `json:"results"`
+		}{Valid: valid, Results: results}, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(string(bz))
+	} else {
+		for _, result := range results {
+			if result.Valid {
+				fmt.Printf("%s: ok\n", validationName(result))
+			} else {
+				fmt.Printf("%s: %s\n", validationName(result), result.Error)
+			}
+		}
+	}
+
+	if !valid {
+		os.Exit(1)
+	}
+}
+
+// validationName returns the name of the validated part of a spec,
+// including its action or protocol identifier if known.
+func validationName(result builder.ValidationResult) string {
+	if result.ID == "" {
+		return result.Path
+	}
+
+	return result.Path + " (" + result.ID + ")"
+}
+
+// runDoctor prints a checklist of the environment
+// and exits with a non-zero code if any check failed.
+func runDoctor(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "report the checks as JSON")
+	_ = fs.Parse(args) // NOTE: errors exit the program due to flag.ExitOnError
+
+	if fs.NArg() > 0 {
+		log.Fatal("usage: orbgen doctor [--json]")
+	}
+
+	checks := builder.RunDoctor()
+
+	ok := true
+	for _, check := range checks {
+		ok = ok && check.OK
+	}
+
+	if *jsonOutput {
+		bz, err := json.MarshalIndent(struct {
+			OK     bool                  `json:"ok"`
+			Checks []builder.DoctorCheck `json:"checks"`
+		}{OK: ok, Checks: checks}, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(string(bz))
+	} else {
+		for _, check := range checks {
+			mark := "[x]"
+			if !check.OK {
+				mark = "[ ]"
+			}
+
+			fmt.Printf("%s %s: %s\n", mark, check.Name, check.Detail)
+		}
+	}
+
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// selectRecent lists the recently generated payloads, or returns the payload with
+// the number given as argument, along with whether it should be edited in the TUI.
+// Nil is returned if the payloads were listed.
+func selectRecent(args []string) (*builder.RecentPayload, bool) {
+	fs := flag.NewFlagSet("recent", flag.ExitOnError)
+	edit := fs.Bool("edit", false, "append actions to the payload in the TUI")
+	_ = fs.Parse(args) // NOTE: errors exit the program due to flag.ExitOnError
+
+	if fs.NArg() > 1 || (*edit && fs.NArg() == 0) {
+		log.Fatal("usage: orbgen recent [[--edit] <number>]")
+	}
+
+	recent, err := builder.LoadRecentPayloads()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if fs.NArg() == 0 {
+		if len(recent) == 0 {
+			fmt.Fprintln(os.Stderr, "no recently generated payloads")
+		}
+
+		for i, r := range recent {
+			fmt.Printf("%d. %s  %s\n", i+1, r.CreatedAt.Format(time.DateTime), r.Label)
+		}
+
+		return nil, false
+	}
+
+	number, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || number < 1 || number > len(recent) {
+		log.Fatalf("invalid payload number %q; expected 1 to %d", fs.Arg(0), len(recent))
+	}
+
+	return &recent[number-1], *edit
+}
+
+// loadSpec loads the spec file at the given path.
+func loadSpec(path string) *builder.Spec {
+	spec, err := builder.LoadSpec(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return &spec
+}
+
+// importQuery builds the payload described by the given query string and decodes it,
+// so that it can be previewed in the TUI.
+func importQuery(query string, opts builder.Options) *core.PayloadWrapper {
+	spec, err := builder.SpecFromQuery(opts, query)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	payload, err := spec.Build(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	wrapper, err := builder.DecodePayload(payload)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return wrapper
+}
+
+// specFromArgs creates the spec described by the positional arguments.
+// It prints the usage and exits if the arguments do not match any supported form.
+func specFromArgs(args []string) *builder.Spec {
+	spec, err := builder.SpecFromArgs(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	return &spec
+}
+
+// printCompletion prints the completion script for the shell given as argument,
+// which is generated from the registered flags.
+func printCompletion(args []string) {
+	if len(args) != 1 {
+		log.Fatal("completion requires exactly one shell as argument; expected bash, zsh or fish")
+	}
+
+	var flags []builder.CompletionFlag
+	flag.VisitAll(func(f *flag.Flag) {
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, builder.CompletionFlag{
+			Name:   f.Name,
+			Usage:  f.Usage,
+			IsBool: ok && boolFlag.IsBoolFlag(),
+		})
+	})
+
+	script, err := builder.CompletionScript(args[0], flags)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Print(script)
+}
+
+// feeFlag collects the fees passed with repeated --add-fee flags.
+// They are parsed once all flags are known, since parsing depends on the options.
+type feeFlag []string
+
+func (f *feeFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *feeFlag) Set(entry string) error {
+	*f = append(*f, entry)
+
+	return nil
+}
+
+// metadataFlag collects the metadata passed with repeated --meta flags.
+type metadataFlag map[string]string
+
+func (f metadataFlag) String() string {
+	entries := make([]string, 0, len(f))
+	for key, value := range f {
+		entries = append(entries, key+"="+value)
+	}
+	slices.Sort(entries)
+
+	return strings.Join(entries, ",")
+}
+
+func (f metadataFlag) Set(entry string) error {
+	key, value, err := builder.ParseMetadata(entry)
+	if err != nil {
+		return err
+	}
+
+	f[key] = value
+
+	return nil
+}
+
+// usage prints the supported invocations and flags.
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage:")
+	fmt.Fprintln(out, "  orbgen [flags]")
+	fmt.Fprintln(out, "  orbgen [flags] cctp <domain> <mint-recipient> [destination-caller]")
+	fmt.Fprintln(out, "  orbgen [flags] internal <recipient>")
+	fmt.Fprintln(out, "  orbgen [flags] --import <payload>")
+	fmt.Fprintln(out, "  orbgen [flags] --edit <payload> --add-fee <recipient>:<basis-points>...")
+	fmt.Fprintln(out, "  orbgen --diff <payload-a> <payload-b>")
+	fmt.Fprintln(out, "  orbgen validate --spec <file> [--json] [--junit <file>]")
+	fmt.Fprintln(out, "  orbgen [flags] recent [[--edit] <number>]")
+	fmt.Fprintln(out, "  orbgen doctor [--json]")
+	fmt.Fprintln(out, "  orbgen completion <bash|zsh|fish>")
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+}
+
+// runInteractive runs the TUI and returns the generated payload along with its output format
+// and the notes of its actions.
+//
+// Unless the output format is given as flag or fixed by other flags, it is selected
+// at the end of the TUI and remembered for the next run.
+func runInteractive(
+	opts builder.Options,
+	cfg builder.Config,
+	format builder.Format,
+	fixedFormat bool,
+	recordPath string,
+) (string, builder.Format, []string) {
+	flag.Visit(func(f *flag.Flag) {
+		fixedFormat = fixedFormat || f.Name == "format"
+	})
+
+	opts.DefaultDestinationCallers = cfg.DefaultDestinationCallers
+	if len(opts.Profile.DefaultDestinationCallers) > 0 {
+		opts.DefaultDestinationCallers = maps.Clone(cfg.DefaultDestinationCallers)
+		if opts.DefaultDestinationCallers == nil {
+			opts.DefaultDestinationCallers = make(map[uint32]string)
+		}
+
+		maps.Copy(opts.DefaultDestinationCallers, opts.Profile.DefaultDestinationCallers)
+	}
+	if !fixedFormat {
+		opts.SelectFormat = true
+		opts.OutputFormat = cfg.OutputFormat
+	}
+
+	payload, selected, notes := runTUI(opts, recordPath)
+	if selected == "" {
+		return payload, format, notes
+	}
+
+	cfg.OutputFormat = selected
+	if err := builder.SaveConfig(cfg); err != nil {
+		log.Printf("warning: failed to remember output format: %v", err)
+	}
+
+	return payload, selected, notes
+}
+
+// runPrompts asks for the payload contents with plain prompts on stderr,
+// which is used if the TUI cannot run in the terminal.
+// If the prompts are not answered completely, it exits with a non-zero code.
+func runPrompts(opts builder.Options) (string, builder.Format, []string) {
+	payload, notes, err := internal.RunPrompts(opts, os.Stdin, os.Stderr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return payload, "", notes
+}
+
+// runTUI runs the interactive payload generator and returns the generated payload,
+// the output format selected in the TUI, if any, and the notes of the actions.
+// If a record path is given, a transcript of the screens is written to it.
+// If the generator is quit before building a payload, it exits with a non-zero code.
+func runTUI(opts builder.Options, recordPath string) (string, builder.Format, []string) {
 	// Setup the TUI model and run it
-	m := internal.InitialModel()
+	model := internal.InitialModel(opts)
+
+	var (
+		m        tea.Model = model
+		recorder *internal.Recorder
+	)
+	if recordPath != "" {
+		f, err := os.Create(recordPath)
+		if err != nil {
+			log.Fatal(fmt.Errorf("failed to create transcript: %w", err))
+		}
+		defer f.Close()
+
+		recorder = internal.NewRecorder(model, f)
+		m = recorder
+	}
+
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	runModel, err := p.Run()
-	if err != nil {
-		log.Fatal(err)
+	// NOTE: an interrupted program still returns its model, so that the progress can be printed.
+	if err != nil && !errors.Is(err, tea.ErrInterrupted) {
+		log.Printf("warning: failed to run the TUI: %v; falling back to prompts", err)
+
+		return runPrompts(opts)
+	}
+
+	if recorder != nil {
+		if runModel, err = recorder.Finish(); err != nil {
+			log.Printf("warning: failed to write transcript: %v", err)
+		}
 	}
 
-	// Print the full payload to stdout when exiting
+	// Return the full payload to be printed to stdout when exiting
 	//
 	// NOTE: This is not handled within the charm stuff to enable copying the full thing.
 	// Within the charm TUI, the output would be truncated to the size of the window.
+	var (
+		payload  string
+		format   builder.Format
+		notes    []string
+		progress []string
+	)
 	if runModel != nil {
 		m, ok := runModel.(internal.Model)
 		if !ok {
 			log.Fatal(fmt.Errorf("unexpected model; got %T", runModel))
 		}
 
-		fmt.Println(m.GetPayload())
+		payload = m.GetPayload()
+		format = m.GetFormat()
+		notes = m.GetActionNotes()
+		progress = m.GetProgress()
 	}
+
+	if payload == "" {
+		if len(progress) > 0 {
+			fmt.Fprintln(os.Stderr, "configured so far:")
+			for _, line := range progress {
+				fmt.Fprintln(os.Stderr, "  "+line)
+			}
+		}
+
+		fmt.Fprintln(os.Stderr, "no payload generated")
+		os.Exit(1)
+	}
+
+	return payload, format, notes
 }
@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noble-assets/orbiter/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noble-assets/orbgen/internal"
+	"github.com/noble-assets/orbgen/internal/builder"
+)
+
+func TestPrintedPayloadMatchesTUIPayload(t *testing.T) {
+	testutil.SetSDKConfig()
+
+	const (
+		mintRecipient = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+		// windowWidth is much narrower than the payload, which must not be truncated.
+		windowWidth = 40
+	)
+
+	// NOTE: the program is cancelled if the flow gets stuck, instead of hanging the test.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := builder.Options{Theme: "no-color"}
+	p := tea.NewProgram(
+		internal.InitialModel(opts),
+		tea.WithContext(ctx),
+		tea.WithInput(nil),
+		tea.WithOutput(io.Discard),
+		tea.WithoutSignalHandler(),
+	)
+
+	go func() {
+		msgs := []tea.Msg{
+			tea.WindowSizeMsg{Width: windowWidth, Height: 20},
+			// Select "No more actions" and the CCTP protocol.
+			tea.KeyMsg{Type: tea.KeyDown},
+			tea.KeyMsg{Type: tea.KeyEnter},
+			tea.KeyMsg{Type: tea.KeyEnter},
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")},
+			tea.KeyMsg{Type: tea.KeyTab},
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(mintRecipient)},
+			// Review and confirm the payload.
+			tea.KeyMsg{Type: tea.KeyEnter},
+			tea.KeyMsg{Type: tea.KeyEnter},
+		}
+		for _, msg := range msgs {
+			p.Send(msg)
+		}
+	}()
+
+	runModel, err := p.Run()
+	require.NoError(t, err)
+
+	m, ok := runModel.(internal.Model)
+	require.True(t, ok, "expected the model; got: %T", runModel)
+
+	fwd, err := builder.NewCCTPForwarding(opts, 6, mintRecipient, "", "")
+	require.NoError(t, err)
+
+	expected, err := builder.BuildPayload(fwd, nil)
+	require.NoError(t, err)
+	require.Greater(t, len(expected), windowWidth)
+	require.Equal(t, expected, m.GetPayload())
+
+	output, err := outputConfig{}.render(m.GetPayload())
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	printOutput(&stdout, output)
+	require.Equal(t, m.GetPayload()+"\n", stdout.String())
+}